@@ -20,6 +20,12 @@ type Boolean struct {
 
 func (e *Boolean) String() string { return e.Token.Value }
 
+type Null struct {
+	Token token.Token
+}
+
+func (e *Null) String() string { return e.Token.Value }
+
 type String struct {
 	Token token.Token
 	Value string
@@ -30,20 +36,36 @@ func (e *String) String() string { return e.Token.Value }
 type Identifier struct {
 	Token token.Token
 	Value string
+
+	// Resolved is set by the resolver when the identifier refers to a local
+	// variable, which is found in slot Slot of the frame Depth scopes out from
+	// the one it's evaluated in. Other identifiers are looked up by name.
+	Resolved    bool
+	Depth, Slot int
 }
 
 func (e *Identifier) String() string { return e.Value }
 
+// Function represents `fn(<parameters>) { <body> }`, or `fn*(...) { ... }` for
+// generator functions.
 type Function struct {
 	Token      token.Token
-	Parameters []*Identifier
+	Parameters []Pattern
 	Body       *BlockStatement
+	Generator  bool
+	// Locals are the names bound in the function's scope, in slot order. They're
+	// set by the resolver.
+	Locals []string
 }
 
 func (e *Function) String() string {
 	var str strings.Builder
 
-	str.WriteString("func ")
+	str.WriteString("func")
+	if e.Generator {
+		str.WriteString("*")
+	}
+	str.WriteString(" ")
 	str.WriteString("(")
 
 	var params []string
@@ -58,6 +80,30 @@ func (e *Function) String() string {
 	return str.String()
 }
 
+// MacroLiteral represents `macro(<parameters>) { <body> }`. Macros are bound by
+// top-level let statements and expanded before the program is evaluated.
+type MacroLiteral struct {
+	Token      token.Token
+	Parameters []*Identifier
+	Body       *BlockStatement
+}
+
+func (e *MacroLiteral) String() string {
+	var str strings.Builder
+
+	var params []string
+	for _, p := range e.Parameters {
+		params = append(params, p.String())
+	}
+
+	str.WriteString("macro(")
+	str.WriteString(strings.Join(params, ","))
+	str.WriteString(")")
+	str.WriteString(e.Body.String())
+
+	return str.String()
+}
+
 type Array struct {
 	Token    token.Token
 	Elements []Expression
@@ -79,17 +125,69 @@ func (a *Array) String() string {
 	return str.String()
 }
 
+// Set represents a set literal, `#{<elements>}`.
+type Set struct {
+	Token    token.Token
+	Elements []Expression
+}
+
+func (s *Set) String() string {
+	var str strings.Builder
+
+	var elements []string
+	for _, e := range s.Elements {
+		elements = append(elements, e.String())
+	}
+
+	str.WriteString("#{")
+	str.WriteString(strings.Join(elements, ", "))
+	str.WriteString("}")
+
+	return str.String()
+}
+
+// Tuple represents a tuple literal: `()`, `(a,)` or `(a, b, ...)`.
+type Tuple struct {
+	Token    token.Token
+	Elements []Expression
+}
+
+func (t *Tuple) String() string {
+	var str strings.Builder
+
+	var elements []string
+	for _, e := range t.Elements {
+		elements = append(elements, e.String())
+	}
+
+	str.WriteString("(")
+	str.WriteString(strings.Join(elements, ", "))
+	if len(elements) == 1 {
+		str.WriteString(",")
+	}
+	str.WriteString(")")
+
+	return str.String()
+}
+
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// Hash represents a hash literal. Pairs are kept in source order so that keys are
+// evaluated (and inserted into the resulting hash) in the order they're written.
 type Hash struct {
 	Token token.Token
-	Pairs map[Expression]Expression
+	Pairs []*HashPair
 }
 
 func (h *Hash) String() string {
 	var str strings.Builder
 
 	pairs := make([]string, 0)
-	for key, value := range h.Pairs {
-		pairs = append(pairs, key.String()+":"+value.String())
+	for _, pair := range h.Pairs {
+		pairs = append(pairs, pair.Key.String()+":"+pair.Value.String())
 	}
 
 	str.WriteString("{")
@@ -120,10 +218,14 @@ func (e InfixExpression) String() string {
 	return fmt.Sprintf("(%s %s %s)", e.Left.String(), e.Operator, e.Right.String())
 }
 
+// IfExpression represents an if statement with an optional else branch. An `else if`
+// chain is represented by setting ElseIf to the next IfExpression in the chain
+// instead of setting Alternative.
 type IfExpression struct {
 	Token       token.Token
 	Condition   Expression
 	Consequence *BlockStatement
+	ElseIf      *IfExpression
 	Alternative *BlockStatement
 }
 
@@ -135,7 +237,10 @@ func (e IfExpression) String() string {
 	str.WriteString(" ")
 	str.WriteString(e.Consequence.String())
 
-	if e.Alternative != nil {
+	if e.ElseIf != nil {
+		str.WriteString("else ")
+		str.WriteString(e.ElseIf.String())
+	} else if e.Alternative != nil {
 		str.WriteString("else")
 		str.WriteString(e.Alternative.String())
 	}
@@ -143,16 +248,107 @@ func (e IfExpression) String() string {
 	return str.String()
 }
 
+// ConditionalExpression represents the ternary `condition ? consequence : alternative`.
+type ConditionalExpression struct {
+	Token       token.Token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (e ConditionalExpression) String() string {
+	return fmt.Sprintf("(%s ? %s : %s)", e.Condition.String(), e.Consequence.String(), e.Alternative.String())
+}
+
+// TryExpression represents `try { } catch (<param>) { } finally { }`, where at
+// least one of the catch or finally blocks is present. CatchParam is optional
+// even if there is a catch block.
+type TryExpression struct {
+	Token      token.Token
+	Body       *BlockStatement
+	CatchParam Pattern
+	Catch      *BlockStatement
+	Finally    *BlockStatement
+	// BodyLocals, CatchLocals and FinallyLocals are the names bound in the scopes
+	// of the try, catch and finally blocks, in slot order. They're set by the
+	// resolver.
+	BodyLocals    []string
+	CatchLocals   []string
+	FinallyLocals []string
+}
+
+func (e TryExpression) String() string {
+	var str strings.Builder
+
+	str.WriteString("try ")
+	str.WriteString(e.Body.String())
+
+	if e.Catch != nil {
+		str.WriteString("catch ")
+		if e.CatchParam != nil {
+			str.WriteString("(" + e.CatchParam.String() + ") ")
+		}
+		str.WriteString(e.Catch.String())
+	}
+
+	if e.Finally != nil {
+		str.WriteString("finally ")
+		str.WriteString(e.Finally.String())
+	}
+
+	return str.String()
+}
+
+// ForExpression represents `for (<variables> in <iterable>) { <body> }`, where
+// there are one or two variables. Arrays and strings bind each element (or the
+// index and element), and hashes bind each key (or the key and value).
+type ForExpression struct {
+	Token     token.Token
+	Variables []Pattern
+	Iterable  Expression
+	Body      *BlockStatement
+	// Locals are the names bound in the scope of each iteration, in slot order.
+	// They're set by the resolver.
+	Locals []string
+}
+
+func (e ForExpression) String() string {
+	var str strings.Builder
+
+	var variables []string
+	for _, v := range e.Variables {
+		variables = append(variables, v.String())
+	}
+
+	str.WriteString("for (")
+	str.WriteString(strings.Join(variables, ", "))
+	str.WriteString(" in ")
+	str.WriteString(e.Iterable.String())
+	str.WriteString(") ")
+	str.WriteString(e.Body.String())
+
+	return str.String()
+}
+
+// CallExpression represents a function call. Optional calls (`f?.(x)`) evaluate
+// to null without calling anything if the function is null.
 type CallExpression struct {
 	Token     token.Token
 	Function  Expression
 	Arguments []Expression
+	Optional  bool
+	// Tail is set by the resolver for calls whose result is returned by the
+	// function they're made in.
+	Tail bool
 }
 
 func (e CallExpression) String() string {
 	var str strings.Builder
 
 	str.WriteString(e.Function.String())
+	if e.Optional {
+		str.WriteString("?.")
+	}
 	str.WriteString("(")
 
 	var params []string
@@ -166,10 +362,13 @@ func (e CallExpression) String() string {
 	return str.String()
 }
 
+// IndexExpression represents `left[index]`. Optional index expressions
+// (`left?[index]`) evaluate to null if left is null.
 type IndexExpression struct {
-	Token token.Token
-	Left  Expression
-	Index Expression
+	Token    token.Token
+	Left     Expression
+	Index    Expression
+	Optional bool
 }
 
 func (ie *IndexExpression) String() string {
@@ -177,6 +376,9 @@ func (ie *IndexExpression) String() string {
 
 	str.WriteString("(")
 	str.WriteString(ie.Left.String())
+	if ie.Optional {
+		str.WriteString("?")
+	}
 	str.WriteString("[")
 	str.WriteString(ie.Index.String())
 	str.WriteString("]")
@@ -184,3 +386,98 @@ func (ie *IndexExpression) String() string {
 
 	return str.String()
 }
+
+// SliceExpression represents `left[low:high]`, where either bound may be omitted.
+// Like index expressions, optional slices (`left?[low:high]`) evaluate to null if
+// left is null.
+type SliceExpression struct {
+	Token    token.Token
+	Left     Expression
+	Low      Expression
+	High     Expression
+	Optional bool
+}
+
+func (se *SliceExpression) String() string {
+	var str strings.Builder
+
+	str.WriteString("(")
+	str.WriteString(se.Left.String())
+	if se.Optional {
+		str.WriteString("?")
+	}
+	str.WriteString("[")
+	if se.Low != nil {
+		str.WriteString(se.Low.String())
+	}
+	str.WriteString(":")
+	if se.High != nil {
+		str.WriteString(se.High.String())
+	}
+	str.WriteString("])")
+
+	return str.String()
+}
+
+// MemberExpression represents `object.property` or `object?.property` (which
+// evaluates to null if object is null). Properties are looked up in the methods
+// of object's type before being used as a string key to index object.
+type MemberExpression struct {
+	Token    token.Token
+	Object   Expression
+	Property *Identifier
+	Optional bool
+}
+
+func (me *MemberExpression) String() string {
+	var str strings.Builder
+
+	str.WriteString("(")
+	str.WriteString(me.Object.String())
+	if me.Optional {
+		str.WriteString("?")
+	}
+	str.WriteString(".")
+	str.WriteString(me.Property.String())
+	str.WriteString(")")
+
+	return str.String()
+}
+
+// YieldExpression represents `yield [<value>]` within the body of a generator
+// function. Value is nil if it's omitted, which yields null.
+type YieldExpression struct {
+	Token token.Token
+	Value Expression
+}
+
+func (e *YieldExpression) String() string {
+	if e.Value == nil {
+		return e.Token.Value
+	}
+	return e.Token.Value + " " + e.Value.String()
+}
+
+// SpawnExpression represents `spawn <expression>`, which runs a function call on
+// a new goroutine. If the expression isn't a call, it must evaluate to a function,
+// which is called without arguments.
+type SpawnExpression struct {
+	Token token.Token
+	Call  Expression
+}
+
+func (e *SpawnExpression) String() string {
+	return e.Token.Value + " " + e.Call.String()
+}
+
+// AssignExpression represents `target = value`, where target is an index or member
+// expression. It updates the element or field in place and evaluates to value.
+type AssignExpression struct {
+	Token  token.Token
+	Target Expression
+	Value  Expression
+}
+
+func (e *AssignExpression) String() string {
+	return fmt.Sprintf("(%s = %s)", e.Target.String(), e.Value.String())
+}
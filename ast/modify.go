@@ -0,0 +1,205 @@
+package ast
+
+// ModifierFunc is called by Modify for every node in a tree and returns the node
+// that should replace it.
+type ModifierFunc func(Node) Node
+
+// Modify walks the tree rooted at node depth-first, replacing each node with the
+// result of calling modifier on it after its children have been modified. The
+// original tree is left untouched: nodes containing modified children are copied
+// rather than updated in place, so the same tree (such as the body of a macro)
+// can safely be modified more than once. Patterns and parameter lists are not
+// walked since they only ever contain identifiers and literals.
+func Modify(node Node, modifier ModifierFunc) Node {
+	switch node := node.(type) {
+	case *AST:
+		n := *node
+		n.Statements = modifyStatements(node.Statements, modifier)
+		return modifier(&n)
+	case *ExpressionStatement:
+		n := *node
+		n.Expression = modifyExpression(node.Expression, modifier)
+		return modifier(&n)
+	case *BlockStatement:
+		return modifier(modifyBlock(node, modifier))
+	case *LetStatement:
+		n := *node
+		n.Value = modifyExpression(node.Value, modifier)
+		return modifier(&n)
+	case *ReturnStatement:
+		n := *node
+		n.Value = modifyExpression(node.Value, modifier)
+		return modifier(&n)
+	case *ThrowStatement:
+		n := *node
+		n.Value = modifyExpression(node.Value, modifier)
+		return modifier(&n)
+	case *ClassStatement:
+		n := *node
+		if node.Parent != nil {
+			n.Parent = modifyExpression(node.Parent, modifier)
+		}
+		n.Methods = make([]*ClassMethod, len(node.Methods))
+		for i, method := range node.Methods {
+			m := *method
+			m.Body = modifyBlock(method.Body, modifier)
+			n.Methods[i] = &m
+		}
+		return modifier(&n)
+	case *PrefixExpression:
+		n := *node
+		n.Right = modifyExpression(node.Right, modifier)
+		return modifier(&n)
+	case *InfixExpression:
+		n := *node
+		n.Left = modifyExpression(node.Left, modifier)
+		n.Right = modifyExpression(node.Right, modifier)
+		return modifier(&n)
+	case *IndexExpression:
+		n := *node
+		n.Left = modifyExpression(node.Left, modifier)
+		n.Index = modifyExpression(node.Index, modifier)
+		return modifier(&n)
+	case *SliceExpression:
+		n := *node
+		n.Left = modifyExpression(node.Left, modifier)
+		if node.Low != nil {
+			n.Low = modifyExpression(node.Low, modifier)
+		}
+		if node.High != nil {
+			n.High = modifyExpression(node.High, modifier)
+		}
+		return modifier(&n)
+	case *MemberExpression:
+		n := *node
+		n.Object = modifyExpression(node.Object, modifier)
+		return modifier(&n)
+	case *YieldExpression:
+		n := *node
+		if node.Value != nil {
+			n.Value = modifyExpression(node.Value, modifier)
+		}
+		return modifier(&n)
+	case *SpawnExpression:
+		n := *node
+		n.Call = modifyExpression(node.Call, modifier)
+		return modifier(&n)
+	case *AssignExpression:
+		n := *node
+		n.Target = modifyExpression(node.Target, modifier)
+		n.Value = modifyExpression(node.Value, modifier)
+		return modifier(&n)
+	case *CallExpression:
+		n := *node
+		n.Function = modifyExpression(node.Function, modifier)
+		n.Arguments = modifyExpressions(node.Arguments, modifier)
+		return modifier(&n)
+	case *IfExpression:
+		return modifier(modifyIf(node, modifier))
+	case *ConditionalExpression:
+		n := *node
+		n.Condition = modifyExpression(node.Condition, modifier)
+		n.Consequence = modifyExpression(node.Consequence, modifier)
+		n.Alternative = modifyExpression(node.Alternative, modifier)
+		return modifier(&n)
+	case *MatchExpression:
+		n := *node
+		n.Subject = modifyExpression(node.Subject, modifier)
+		n.Arms = make([]*MatchArm, len(node.Arms))
+		for i, arm := range node.Arms {
+			a := *arm
+			if arm.Guard != nil {
+				a.Guard = modifyExpression(arm.Guard, modifier)
+			}
+			a.Body = Modify(arm.Body, modifier)
+			n.Arms[i] = &a
+		}
+		return modifier(&n)
+	case *TryExpression:
+		n := *node
+		n.Body = modifyBlock(node.Body, modifier)
+		if node.Catch != nil {
+			n.Catch = modifyBlock(node.Catch, modifier)
+		}
+		if node.Finally != nil {
+			n.Finally = modifyBlock(node.Finally, modifier)
+		}
+		return modifier(&n)
+	case *ForExpression:
+		n := *node
+		n.Iterable = modifyExpression(node.Iterable, modifier)
+		n.Body = modifyBlock(node.Body, modifier)
+		return modifier(&n)
+	case *Function:
+		n := *node
+		n.Body = modifyBlock(node.Body, modifier)
+		return modifier(&n)
+	case *Array:
+		n := *node
+		n.Elements = modifyExpressions(node.Elements, modifier)
+		return modifier(&n)
+	case *Set:
+		n := *node
+		n.Elements = modifyExpressions(node.Elements, modifier)
+		return modifier(&n)
+	case *Tuple:
+		n := *node
+		n.Elements = modifyExpressions(node.Elements, modifier)
+		return modifier(&n)
+	case *Hash:
+		n := *node
+		n.Pairs = make([]*HashPair, len(node.Pairs))
+		for i, pair := range node.Pairs {
+			n.Pairs[i] = &HashPair{
+				Key:   modifyExpression(pair.Key, modifier),
+				Value: modifyExpression(pair.Value, modifier),
+			}
+		}
+		return modifier(&n)
+	}
+
+	return modifier(node)
+}
+
+func modifyExpression(exp Expression, modifier ModifierFunc) Expression {
+	modified, _ := Modify(exp, modifier).(Expression)
+	return modified
+}
+
+func modifyExpressions(exps []Expression, modifier ModifierFunc) []Expression {
+	modified := make([]Expression, len(exps))
+	for i, exp := range exps {
+		modified[i] = modifyExpression(exp, modifier)
+	}
+	return modified
+}
+
+func modifyStatements(statements []Statement, modifier ModifierFunc) []Statement {
+	modified := make([]Statement, len(statements))
+	for i, statement := range statements {
+		modified[i], _ = Modify(statement, modifier).(Statement)
+	}
+	return modified
+}
+
+// blocks and else-if branches are stored by their concrete types, so their children
+// are modified but they aren't passed to modifier themselves.
+
+func modifyBlock(block *BlockStatement, modifier ModifierFunc) *BlockStatement {
+	n := *block
+	n.Statements = modifyStatements(block.Statements, modifier)
+	return &n
+}
+
+func modifyIf(ie *IfExpression, modifier ModifierFunc) *IfExpression {
+	n := *ie
+	n.Condition = modifyExpression(ie.Condition, modifier)
+	n.Consequence = modifyBlock(ie.Consequence, modifier)
+	if ie.ElseIf != nil {
+		n.ElseIf = modifyIf(ie.ElseIf, modifier)
+	}
+	if ie.Alternative != nil {
+		n.Alternative = modifyBlock(ie.Alternative, modifier)
+	}
+	return &n
+}
@@ -0,0 +1,70 @@
+package ast
+
+import (
+	"monkey-interpreter/token"
+	"testing"
+)
+
+func TestModify(t *testing.T) {
+	one := func() Expression { return &Integer{Token: token.Token{Type: token.INT, Value: "1"}, Value: 1} }
+	two := func() Expression { return &Integer{Token: token.Token{Type: token.INT, Value: "2"}, Value: 2} }
+
+	turnOneIntoTwo := func(node Node) Node {
+		integer, ok := node.(*Integer)
+		if !ok || integer.Value != 1 {
+			return node
+		}
+		return two()
+	}
+
+	block := func(e Expression) *BlockStatement {
+		return &BlockStatement{Statements: []Statement{&ExpressionStatement{Expression: e}}}
+	}
+
+	tests := []struct {
+		input    Node
+		expected string
+	}{
+		{one(), "2"},
+		{&AST{Statements: []Statement{&ExpressionStatement{Expression: one()}}}, "2"},
+		{&InfixExpression{Left: one(), Operator: "+", Right: two()}, "(2 + 2)"},
+		{&InfixExpression{Left: two(), Operator: "+", Right: one()}, "(2 + 2)"},
+		{&PrefixExpression{Operator: "-", Right: one()}, "(-2)"},
+		{&IndexExpression{Left: one(), Index: one()}, "(2[2])"},
+		{&MemberExpression{Object: one(), Property: &Identifier{Value: "x"}, Optional: true}, "(2?.x)"},
+		{&YieldExpression{Token: token.Token{Value: "yield"}, Value: one()}, "yield 2"},
+		{&SpawnExpression{Token: token.Token{Value: "spawn"}, Call: &CallExpression{Function: &Identifier{Value: "f"}, Arguments: []Expression{one()}}}, "spawn f(2)"},
+		{&AssignExpression{Target: &IndexExpression{Left: one(), Index: one()}, Value: one()}, "((2[2]) = 2)"},
+		{&CallExpression{Function: &Identifier{Value: "f"}, Arguments: []Expression{one(), two()}}, "f(2, 2)"},
+		{&IfExpression{Condition: one(), Consequence: block(one()), Alternative: block(one())}, "if 2 2else2"},
+		{
+			&IfExpression{Condition: one(), Consequence: block(one()), ElseIf: &IfExpression{Condition: one(), Consequence: block(one())}},
+			"if 2 2else if 2 2",
+		},
+		{&ConditionalExpression{Condition: one(), Consequence: one(), Alternative: one()}, "(2 ? 2 : 2)"},
+		{&ReturnStatement{Token: token.Token{Value: "return"}, Value: one()}, "return 2;"},
+		{&ThrowStatement{Token: token.Token{Value: "throw"}, Value: one()}, "throw 2;"},
+		{&LetStatement{Token: token.Token{Value: "let"}, Name: &Identifier{Value: "x"}, Value: one()}, "let x = 2;"},
+		{&Function{Body: block(one())}, "func ()2"},
+		{&Array{Elements: []Expression{one(), one()}}, "[2, 2]"},
+		{&Hash{Pairs: []*HashPair{{Key: one(), Value: one()}}}, "{2:2}"},
+		{&TryExpression{Body: block(one()), Catch: block(one()), Finally: block(one())}, "try 2catch 2finally 2"},
+		{
+			&MatchExpression{Subject: one(), Arms: []*MatchArm{{Pattern: one(), Guard: one(), Body: one()}}},
+			"match (2) {1 if 2 => 2}",
+		},
+	}
+
+	for _, tt := range tests {
+		original := tt.input.String()
+		modified := Modify(tt.input, turnOneIntoTwo)
+
+		if modified.String() != tt.expected {
+			t.Errorf("wrong result of Modify. want=%q, got=%q", tt.expected, modified.String())
+		}
+
+		if tt.input.String() != original {
+			t.Errorf("Modify changed the original tree. want=%q, got=%q", original, tt.input.String())
+		}
+	}
+}
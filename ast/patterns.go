@@ -0,0 +1,164 @@
+package ast
+
+import (
+	"monkey-interpreter/token"
+	"strings"
+)
+
+// Pattern is a node describing the shape of a value. Identifiers bind the value
+// they're matched against (with `_` acting as a wildcard), literals and member
+// expressions (such as `Shape.Empty`) match equal values, and array, hash and
+// constructor patterns destructure their elements.
+type Pattern interface {
+	Node
+}
+
+// PatternNames returns the names of the identifiers bound by pattern, in order.
+func PatternNames(pattern Pattern) []string {
+	var names []string
+
+	switch pattern := pattern.(type) {
+	case *Identifier:
+		if pattern.Value != "_" {
+			names = append(names, pattern.Value)
+		}
+	case *ArrayPattern:
+		for _, element := range pattern.Elements {
+			names = append(names, PatternNames(element)...)
+		}
+		if pattern.Rest != nil {
+			names = append(names, PatternNames(pattern.Rest)...)
+		}
+	case *ConstructorPattern:
+		for _, argument := range pattern.Arguments {
+			names = append(names, PatternNames(argument)...)
+		}
+	case *HashPattern:
+		for _, pair := range pattern.Pairs {
+			names = append(names, PatternNames(pair.Value)...)
+		}
+		if pattern.Rest != nil {
+			names = append(names, PatternNames(pattern.Rest)...)
+		}
+	}
+
+	return names
+}
+
+type ArrayPattern struct {
+	Token    token.Token
+	Elements []Pattern
+	Rest     *Identifier
+}
+
+func (p *ArrayPattern) String() string {
+	var str strings.Builder
+
+	elements := make([]string, 0)
+	for _, e := range p.Elements {
+		elements = append(elements, e.String())
+	}
+	if p.Rest != nil {
+		elements = append(elements, "..."+p.Rest.String())
+	}
+
+	str.WriteString("[")
+	str.WriteString(strings.Join(elements, ", "))
+	str.WriteString("]")
+
+	return str.String()
+}
+
+type HashPatternPair struct {
+	Key   Expression
+	Value Pattern
+}
+
+type HashPattern struct {
+	Token token.Token
+	Pairs []*HashPatternPair
+	Rest  *Identifier
+}
+
+func (p *HashPattern) String() string {
+	var str strings.Builder
+
+	pairs := make([]string, 0)
+	for _, pair := range p.Pairs {
+		pairs = append(pairs, pair.Key.String()+":"+pair.Value.String())
+	}
+	if p.Rest != nil {
+		pairs = append(pairs, "..."+p.Rest.String())
+	}
+
+	str.WriteString("{")
+	str.WriteString(strings.Join(pairs, ", "))
+	str.WriteString("}")
+
+	return str.String()
+}
+
+// ConstructorPattern represents `<constructor>(<patterns>)`, which matches values
+// built by the constructor (an enum variant such as `Shape.Circle`, or a struct)
+// and destructures their fields in the order they were declared.
+type ConstructorPattern struct {
+	Token       token.Token
+	Constructor Expression
+	Arguments   []Pattern
+}
+
+func (p *ConstructorPattern) String() string {
+	arguments := make([]string, 0)
+	for _, argument := range p.Arguments {
+		arguments = append(arguments, argument.String())
+	}
+
+	return p.Constructor.String() + "(" + strings.Join(arguments, ", ") + ")"
+}
+
+type MatchArm struct {
+	Pattern Pattern
+	Guard   Expression
+	// Body is either an Expression or a *BlockStatement.
+	Body Node
+	// Locals are the names bound in the arm's scope, in slot order. They're set
+	// by the resolver.
+	Locals []string
+}
+
+func (a *MatchArm) String() string {
+	var str strings.Builder
+
+	str.WriteString(a.Pattern.String())
+	if a.Guard != nil {
+		str.WriteString(" if ")
+		str.WriteString(a.Guard.String())
+	}
+	str.WriteString(" => ")
+	str.WriteString(a.Body.String())
+
+	return str.String()
+}
+
+type MatchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+func (e *MatchExpression) String() string {
+	var str strings.Builder
+
+	arms := make([]string, 0)
+	for _, arm := range e.Arms {
+		arms = append(arms, arm.String())
+	}
+
+	str.WriteString("match (")
+	str.WriteString(e.Subject.String())
+	str.WriteString(") {")
+	str.WriteString(strings.Join(arms, ", "))
+	str.WriteString("}")
+
+	return str.String()
+}
@@ -6,9 +6,11 @@ import (
 	"strings"
 )
 
+// LetStatement binds the result of Value to Name, which is either an *Identifier
+// or an array or hash pattern that destructures the value.
 type LetStatement struct {
 	Token token.Token
-	Name  *Identifier
+	Name  Pattern
 	Value Expression
 }
 
@@ -25,6 +27,15 @@ func (s ReturnStatement) String() string {
 	return fmt.Sprintf("%s %s;", s.Token.Value, s.Value.String())
 }
 
+type ThrowStatement struct {
+	Token token.Token
+	Value Expression
+}
+
+func (s ThrowStatement) String() string {
+	return fmt.Sprintf("%s %s;", s.Token.Value, s.Value.String())
+}
+
 type ExpressionStatement struct {
 	Token      token.Token
 	Expression Expression
@@ -48,3 +59,156 @@ func (s BlockStatement) String() string {
 
 	return str.String()
 }
+
+// ImportStatement represents either `import "<path>" as <alias>;`, which binds the
+// module itself to Alias, or `import { <names> } from "<path>";`, which binds the
+// named exports of the module.
+type ImportStatement struct {
+	Token token.Token
+	Path  *String
+	Alias *Identifier
+	Names []*Identifier
+}
+
+func (s ImportStatement) String() string {
+	if s.Names != nil {
+		var names []string
+		for _, name := range s.Names {
+			names = append(names, name.String())
+		}
+		return fmt.Sprintf("%s {%s} from %q;", s.Token.Value, strings.Join(names, ", "), s.Path.Value)
+	}
+
+	if s.Alias != nil {
+		return fmt.Sprintf("%s %q as %s;", s.Token.Value, s.Path.Value, s.Alias)
+	}
+	return fmt.Sprintf("%s %q;", s.Token.Value, s.Path.Value)
+}
+
+// StructStatement represents `struct <name> { <fields> }`, which binds Name to a
+// constructor for values with exactly the given fields.
+type StructStatement struct {
+	Token  token.Token
+	Name   *Identifier
+	Fields []*Identifier
+}
+
+func (s StructStatement) String() string {
+	if len(s.Fields) == 0 {
+		return fmt.Sprintf("%s %s {}", s.Token.Value, s.Name)
+	}
+
+	var fields []string
+	for _, field := range s.Fields {
+		fields = append(fields, field.String())
+	}
+	return fmt.Sprintf("%s %s { %s }", s.Token.Value, s.Name, strings.Join(fields, ", "))
+}
+
+// ClassStatement represents `class <name> [extends <parent>] { <methods> }`,
+// which binds Name to a class whose instances have the given methods in addition
+// to those inherited from Parent (if any).
+type ClassStatement struct {
+	Token   token.Token
+	Name    *Identifier
+	Parent  Expression
+	Methods []*ClassMethod
+}
+
+func (s ClassStatement) String() string {
+	var str strings.Builder
+
+	str.WriteString(s.Token.Value + " " + s.Name.String())
+	if s.Parent != nil {
+		str.WriteString(" extends " + s.Parent.String())
+	}
+	str.WriteString(" {")
+	for _, method := range s.Methods {
+		str.WriteString(" " + method.String())
+	}
+	str.WriteString(" }")
+
+	return str.String()
+}
+
+// ClassMethod represents `<name>(<parameters>) { <body> }` within a class body.
+// Methods are called with `self` bound to the instance they were looked up on.
+type ClassMethod struct {
+	Name       *Identifier
+	Parameters []Pattern
+	Body       *BlockStatement
+	// Locals are the names bound in the method's scope, in slot order. They're
+	// set by the resolver.
+	Locals []string
+}
+
+func (m *ClassMethod) String() string {
+	var params []string
+	for _, p := range m.Parameters {
+		params = append(params, p.String())
+	}
+
+	return fmt.Sprintf("%s(%s) %s", m.Name, strings.Join(params, ", "), m.Body)
+}
+
+// EnumStatement represents `enum <name> { <variants> }`, which binds Name to an
+// enum whose variants are accessed as members (`Shape.Circle`).
+type EnumStatement struct {
+	Token    token.Token
+	Name     *Identifier
+	Variants []*EnumVariant
+}
+
+func (s EnumStatement) String() string {
+	var variants []string
+	for _, variant := range s.Variants {
+		variants = append(variants, variant.String())
+	}
+	return fmt.Sprintf("%s %s { %s }", s.Token.Value, s.Name, strings.Join(variants, ", "))
+}
+
+// EnumVariant represents `<name>(<fields>)` within an enum body. Variants without
+// fields are written without parentheses and have nil Fields.
+type EnumVariant struct {
+	Name   *Identifier
+	Fields []*Identifier
+}
+
+func (v *EnumVariant) String() string {
+	if v.Fields == nil {
+		return v.Name.String()
+	}
+
+	var fields []string
+	for _, field := range v.Fields {
+		fields = append(fields, field.String())
+	}
+	return fmt.Sprintf("%s(%s)", v.Name, strings.Join(fields, ", "))
+}
+
+// ExportStatement marks the names bound by a top-level declaration (a let,
+// struct, class or enum statement) as exported from the module that contains it.
+type ExportStatement struct {
+	Token     token.Token
+	Statement Statement
+}
+
+func (s ExportStatement) String() string {
+	return s.Token.Value + " " + s.Statement.String()
+}
+
+// Names returns the names bound by the exported declaration.
+func (s ExportStatement) Names() []string {
+	switch statement := s.Statement.(type) {
+	case *LetStatement:
+		return PatternNames(statement.Name)
+	case *StructStatement:
+		return []string{statement.Name.Value}
+	case *ClassStatement:
+		return []string{statement.Name.Value}
+	case *EnumStatement:
+		return []string{statement.Name.Value}
+	default:
+		return nil
+	}
+}
@@ -4,20 +4,48 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"monkey-interpreter/ast"
 	"monkey-interpreter/evaluator"
 	"monkey-interpreter/lexer"
 	"monkey-interpreter/object"
 	"monkey-interpreter/parser"
 	"os"
+	"path/filepath"
 )
 
+// MONKEYPATH is the environment variable listing the directories searched for
+// imported modules, separated in the same way as PATH.
+const MONKEYPATH = "MONKEYPATH"
+
 func main() {
+	evaluator.Modules.SearchPaths = filepath.SplitList(os.Getenv(MONKEYPATH))
+
+	if len(os.Args) > 1 {
+		os.Exit(Run(os.Args[1], os.Stdout))
+	}
+
 	Start(os.Stdin, os.Stdout)
 }
 
+// Run evaluates the script at path, returning the exit code for the program.
+func Run(path string, out io.Writer) int {
+	evaluated := evaluator.RunFile(path)
+
+	if errObj, ok := evaluated.(*object.Error); ok {
+		io.WriteString(out, errObj.Inspect()+"\n")
+		for _, frame := range errObj.Stack {
+			io.WriteString(out, "\tat "+frame+"\n")
+		}
+		return 1
+	}
+
+	return 0
+}
+
 func Start(in io.Reader, out io.Writer) {
 	stdinReader := bufio.NewScanner(in)
 	env := object.NewEnvironment()
+	macroEnv := object.NewEnvironment()
 
 	for {
 		fmt.Print(">> ")
@@ -38,6 +66,21 @@ func Start(in io.Reader, out io.Writer) {
 			continue
 		}
 
+		evaluator.DefineMacros(program, macroEnv)
+		expanded, err := evaluator.ExpandMacros(program, macroEnv)
+		if err != nil {
+			io.WriteString(out, "\t"+err.Error()+"\n")
+			continue
+		}
+
+		program = expanded.(*ast.AST)
+		if errs := evaluator.Resolve(program, env); len(errs) != 0 {
+			for _, err := range errs {
+				io.WriteString(out, "\t"+err.Error()+"\n")
+			}
+			continue
+		}
+
 		evaluated := evaluator.Eval(program, env)
 		if evaluated != nil {
 			io.WriteString(out, evaluated.Inspect())
@@ -3,6 +3,7 @@ package evaluator
 import (
 	"fmt"
 	"monkey-interpreter/object"
+	"unicode/utf8"
 )
 
 var builtins = map[string]*object.Builtin{
@@ -16,9 +17,15 @@ var builtins = map[string]*object.Builtin{
 
 			switch arg := args[0].(type) {
 			case *object.String:
-				size = len(arg.Value)
+				size = utf8.RuneCountInString(arg.Value)
 			case *object.Array:
 				size = len(arg.Elements)
+			case *object.Hash:
+				size = len(arg.Pairs)
+			case *object.Set:
+				size = len(arg.Elements)
+			case *object.Tuple:
+				size = len(arg.Elements)
 			default:
 				return newError("argument to `len` not supported, got %s", args[0].Type())
 			}
@@ -68,12 +75,13 @@ var builtins = map[string]*object.Builtin{
 
 			switch arg := args[0].(type) {
 			case *object.Array:
-				tailArray := make([]object.Object, len(arg.Elements)-1)
-
-				if len(arg.Elements) > 1 {
-					copy(tailArray, arg.Elements[1:])
+				if len(arg.Elements) == 0 {
+					return NULL
 				}
 
+				tailArray := make([]object.Object, len(arg.Elements)-1)
+				copy(tailArray, arg.Elements[1:])
+
 				return &object.Array{Elements: tailArray}
 			default:
 				return newError("argument to `tail` not supported, got %s", args[0].Type())
@@ -88,14 +96,9 @@ var builtins = map[string]*object.Builtin{
 
 			switch arg := args[0].(type) {
 			case *object.Array:
-				newArray := make([]object.Object, len(arg.Elements)-1)
-				if len(arg.Elements) > 1 {
-					copy(newArray, arg.Elements[1:])
-				}
-
-				for _, element := range args[1:] {
-					newArray = append(newArray, element)
-				}
+				newArray := make([]object.Object, len(arg.Elements), len(arg.Elements)+len(args)-1)
+				copy(newArray, arg.Elements)
+				newArray = append(newArray, args[1:]...)
 
 				return &object.Array{Elements: newArray}
 			default:
@@ -103,6 +106,42 @@ var builtins = map[string]*object.Builtin{
 			}
 		},
 	},
+	// set returns a set of the elements of an array, tuple or set, or an empty set
+	// if called without arguments.
+	"set": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) > 1 {
+				return newError("wrong number of arguments. got=%d, want=0 or 1", len(args))
+			}
+
+			if len(args) == 0 {
+				return object.NewSet()
+			}
+
+			elements, ok := sequenceElements(args[0])
+			if !ok {
+				return newError("argument to `set` not supported, got %s", args[0].Type())
+			}
+			return newSet(elements)
+		},
+	},
+	// tuple returns a tuple of the elements of an array, tuple or set.
+	"tuple": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", len(args))
+			}
+
+			elements, ok := sequenceElements(args[0])
+			if !ok {
+				return newError("argument to `tuple` not supported, got %s", args[0].Type())
+			}
+
+			tuple := make([]object.Object, len(elements))
+			copy(tuple, elements)
+			return &object.Tuple{Elements: tuple}
+		},
+	},
 	"print": {
 		Fn: func(args ...object.Object) object.Object {
 			for _, arg := range args {
@@ -0,0 +1,103 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+func evalClassStatement(node *ast.ClassStatement, env *object.Environment) object.Object {
+	class := &object.Class{Name: node.Name.Value, Methods: make(map[string]*object.Function)}
+
+	if node.Parent != nil {
+		parent := Eval(node.Parent, env)
+		if isError(parent) {
+			return parent
+		}
+
+		parentClass, ok := parent.(*object.Class)
+		if !ok {
+			return newError("class %s cannot extend %s", class.Name, parent.Type())
+		}
+		class.Parent = parentClass
+	}
+
+	env.Share()
+	for _, method := range node.Methods {
+		class.Methods[method.Name.Value] = &object.Function{
+			Name:       class.Name + "." + method.Name.Value,
+			Parameters: method.Parameters,
+			Body:       method.Body,
+			Env:        env,
+			Locals:     method.Locals,
+		}
+	}
+
+	if err := define(env, class.Name, class); err != nil {
+		return err
+	}
+	return nil
+}
+
+// newInstance constructs an instance of class, passing args to its init method.
+// Classes without an init method take no arguments.
+func newInstance(class *object.Class, args []object.Object) object.Object {
+	instance := object.NewInstance(class)
+
+	init, definedBy, ok := class.FindMethod("init")
+	if !ok {
+		if len(args) != 0 {
+			return newError("wrong number of arguments. got=%d, want=0", len(args))
+		}
+		return instance
+	}
+
+	if result := applyFunction(bindMethod(instance, init, definedBy), args); isError(result) {
+		return result
+	}
+	return instance
+}
+
+// methodLocals are the names bound by bindMethod, in the slots it sets them in,
+// which the resolver treats as a scope enclosing the body of each method.
+var methodLocals = []string{"self", "super"}
+
+// bindMethod returns a copy of method whose environment binds `self` to instance
+// and, if definedBy extends another class, `super` to the parent's methods.
+func bindMethod(instance *object.Instance, method *object.Function, definedBy *object.Class) *object.Function {
+	env := object.NewFrame(method.Env, methodLocals)
+	env.SetAt(0, 0, instance)
+	if definedBy.Parent != nil {
+		env.SetAt(0, 1, &object.Super{Instance: instance, Class: definedBy.Parent})
+	}
+	env.Share()
+
+	return &object.Function{
+		Name:       method.Name,
+		Parameters: method.Parameters,
+		Body:       method.Body,
+		Env:        env,
+		Locals:     method.Locals,
+	}
+}
+
+// evalInstanceMember returns the field called name, or else the method called
+// name bound to the instance. Fields shadow methods.
+func evalInstanceMember(instance *object.Instance, name string) object.Object {
+	if value, ok := instance.Fields[name]; ok {
+		return value
+	}
+
+	if method, definedBy, ok := instance.Class.FindMethod(name); ok {
+		return bindMethod(instance, method, definedBy)
+	}
+
+	return newError("%s has no field or method %s", instance.Class.Name, name)
+}
+
+func evalSuperMember(super *object.Super, name string) object.Object {
+	if method, definedBy, ok := super.Class.FindMethod(name); ok {
+		return bindMethod(super.Instance, method, definedBy)
+	}
+
+	return newError("%s has no method %s", super.Class.Name, name)
+}
@@ -0,0 +1,75 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestClasses(t *testing.T) {
+	animals := `
+		class Animal {
+			init(name) { self.name = name }
+			speak() { self.name + " makes a sound" }
+			describe() { "I am " + self.name }
+		}
+		class Dog extends Animal {
+			init(name, trick) {
+				super.init(name);
+				self.trick = trick;
+			}
+			speak() { self.name + " barks" }
+			parent() { super.speak() }
+		}
+		class Puppy extends Dog {
+			speak() { super.speak() + " softly" }
+		}
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{animals + "Animal", inspected{object.CLASS_OBJ, "class Animal"}},
+		{animals + "Dog", inspected{object.CLASS_OBJ, "class Dog extends Animal"}},
+		{animals + `Animal("cat")`, expectedInstance{"Animal", map[string]interface{}{"name": "cat"}}},
+		{animals + `Dog("rex", "sit")`, expectedInstance{"Dog", map[string]interface{}{"name": "rex", "trick": "sit"}}},
+		{animals + `Animal("cat").speak()`, "cat makes a sound"},
+		{animals + `Dog("rex", "sit").speak()`, "rex barks"},
+		{animals + `Dog("rex", "sit").describe()`, "I am rex"},
+		{animals + `Dog("rex", "sit").parent()`, "rex makes a sound"},
+		{animals + `Puppy("bo", "roll").speak()`, "bo barks softly"},
+		{animals + `let d = Dog("rex", "sit"); let speak = d.speak; d.name = "max"; speak()`, "max barks"},
+		{animals + `map([Animal("a"), Dog("b", "c")], fn(a) { a.speak() })`, []interface{}{"a makes a sound", "b barks"}},
+		{animals + `let d = Dog("rex", "sit"); d == d`, true},
+		{animals + `Dog("rex", "sit") == Dog("rex", "sit")`, false},
+		{animals + `Dog("rex", "sit").fly()`, expectedError("Dog has no field or method fly")},
+		{animals + `Animal("cat").parent()`, expectedError("Animal has no field or method parent")},
+		{animals + `Dog("rex")`, expectedError("wrong number of arguments. got=1, want=2")},
+		{"class Empty {}; Empty()", expectedInstance{"Empty", nil}},
+		{"class Empty {}; Empty(1)", expectedError("wrong number of arguments. got=1, want=0")},
+		{"class Counter { init() { self.n = 0 } inc() { self.n = self.n + 1; self } }; Counter().inc().inc().n", 2},
+		{"class A { f() { super.f() } }; A().f()", expectedError("identifier not found: super")},
+		{"class B { g() { 1 } }; class A extends B { f() { super.f() } }; A().f()", expectedError("B has no method f")},
+		{"class A extends 1 {}", expectedError("class A cannot extend integer")},
+		{"class A { f() { g() } }; A().f()", expectedError("identifier not found: g")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestMethodStackTrace(t *testing.T) {
+	evaluated := testEval(`class A { f() { throw "oops" } }; let a = A(); a.f()`)
+
+	err, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("expected an error, got %s", evaluated.Inspect())
+	}
+	if len(err.Stack) != 1 || err.Stack[0] != "A.f" {
+		t.Errorf("wrong stack. want=[A.f], got=%v", err.Stack)
+	}
+}
@@ -0,0 +1,402 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"sort"
+)
+
+// The collection builtins call back into Monkey functions through applyFunction,
+// which depends on builtins itself, so they're registered here rather than in the
+// builtins literal to avoid an initialization cycle.
+func init() {
+	collectionBuiltins := map[string]*object.Builtin{
+		"map":     {Fn: builtinMap},
+		"filter":  {Fn: builtinFilter},
+		"reduce":  {Fn: builtinReduce},
+		"sort":    {Fn: builtinSort},
+		"find":    {Fn: builtinFind},
+		"any":     {Fn: builtinAny},
+		"all":     {Fn: builtinAll},
+		"zip":     {Fn: builtinZip},
+		"range":   {Fn: builtinRange},
+		"reverse": {Fn: builtinReverse},
+		"flatten": {Fn: builtinFlatten},
+		"uniq":    {Fn: builtinUniq},
+	}
+
+	for name, builtin := range collectionBuiltins {
+		builtins[name] = builtin
+	}
+
+	// std/list exports the native versions rather than reimplementing them.
+	for _, name := range []string{"map", "filter", "reduce", "reverse"} {
+		stdModules["list"][name] = collectionBuiltins[name]
+	}
+}
+
+// builtinMap returns an array of the results of calling a function on each
+// element of an array, or an iterator producing them lazily if given an iterator.
+func builtinMap(args ...object.Object) object.Object {
+	if iterator, fn, ok := iteratorAndFunctionArgs(args); ok {
+		return mapIterator(iterator, fn)
+	}
+
+	array, fn, err := arrayAndFunctionArgs("map", args)
+	if err != nil {
+		return err
+	}
+
+	elements := make([]object.Object, len(array.Elements))
+	for i, element := range array.Elements {
+		result := applyFunction(fn, []object.Object{element})
+		if isError(result) {
+			return result
+		}
+		elements[i] = result
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+// builtinFilter returns the elements of an array for which a function returns a
+// truthy value, or an iterator producing them lazily if given an iterator.
+func builtinFilter(args ...object.Object) object.Object {
+	if iterator, fn, ok := iteratorAndFunctionArgs(args); ok {
+		return filterIterator(iterator, fn)
+	}
+
+	array, fn, err := arrayAndFunctionArgs("filter", args)
+	if err != nil {
+		return err
+	}
+
+	elements := make([]object.Object, 0)
+	for _, element := range array.Elements {
+		result := applyFunction(fn, []object.Object{element})
+		if isError(result) {
+			return result
+		}
+		if isTruthy(result) {
+			elements = append(elements, element)
+		}
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+// builtinReduce folds the elements of an array or iterator into a single value
+// by calling a function with the result so far and each element.
+func builtinReduce(args ...object.Object) object.Object {
+	if len(args) != 3 {
+		return newError("wrong number of arguments. got=%d, want=3", len(args))
+	}
+
+	if args[0].Type() != object.ARRAY_OBJ && args[0].Type() != object.ITERATOR_OBJ {
+		return newError("argument to `reduce` not supported, got %s", args[0].Type())
+	}
+	if !isCallable(args[2]) {
+		return newError("argument to `reduce` not supported, got %s", args[2].Type())
+	}
+
+	iterator, _ := iterate(args[0])
+	result := args[1]
+	for element, ok := iterator.Next(); ok; element, ok = iterator.Next() {
+		if isError(element) {
+			return element
+		}
+
+		result = applyFunction(args[2], []object.Object{result, element})
+		if isError(result) {
+			iterator.Stop()
+			return result
+		}
+	}
+
+	return result
+}
+
+// builtinSort returns a sorted copy of an array. Without a comparator, the array
+// must contain only integers, only strings or only instances of classes that
+// overload `<`; otherwise the comparator is called with two elements and returns
+// whether the first should be sorted before the second.
+func builtinSort(args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=1 or 2", len(args))
+	}
+
+	array, ok := args[0].(*object.Array)
+	if !ok {
+		return newError("argument to `sort` not supported, got %s", args[0].Type())
+	}
+
+	less := defaultLess
+	if len(args) == 2 {
+		if !isCallable(args[1]) {
+			return newError("argument to `sort` not supported, got %s", args[1].Type())
+		}
+		less = func(a, b object.Object) object.Object {
+			return applyFunction(args[1], []object.Object{a, b})
+		}
+	}
+
+	elements := make([]object.Object, len(array.Elements))
+	copy(elements, array.Elements)
+
+	var err object.Object
+	sort.SliceStable(elements, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+
+		result := less(elements[i], elements[j])
+		if isError(result) {
+			err = result
+			return false
+		}
+		return isTruthy(result)
+	})
+	if err != nil {
+		return err
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+func defaultLess(a, b object.Object) object.Object {
+	if result, ok := callSpecialMethod(a, "__lt__", b); ok {
+		return result
+	}
+
+	switch {
+	case a.Type() == object.INTEGER_OBJ && b.Type() == object.INTEGER_OBJ:
+		return boolToBooleanObject(a.(*object.Integer).Value < b.(*object.Integer).Value)
+	case a.Type() == object.STRING_OBJ && b.Type() == object.STRING_OBJ:
+		return boolToBooleanObject(a.(*object.String).Value < b.(*object.String).Value)
+	default:
+		return newError("cannot compare %s and %s without a comparator", a.Type(), b.Type())
+	}
+}
+
+func builtinFind(args ...object.Object) object.Object {
+	array, fn, err := arrayAndFunctionArgs("find", args)
+	if err != nil {
+		return err
+	}
+
+	for _, element := range array.Elements {
+		result := applyFunction(fn, []object.Object{element})
+		if isError(result) {
+			return result
+		}
+		if isTruthy(result) {
+			return element
+		}
+	}
+
+	return NULL
+}
+
+func builtinAny(args ...object.Object) object.Object {
+	array, fn, err := arrayAndFunctionArgs("any", args)
+	if err != nil {
+		return err
+	}
+
+	for _, element := range array.Elements {
+		result := applyFunction(fn, []object.Object{element})
+		if isError(result) {
+			return result
+		}
+		if isTruthy(result) {
+			return TRUE
+		}
+	}
+
+	return FALSE
+}
+
+func builtinAll(args ...object.Object) object.Object {
+	array, fn, err := arrayAndFunctionArgs("all", args)
+	if err != nil {
+		return err
+	}
+
+	for _, element := range array.Elements {
+		result := applyFunction(fn, []object.Object{element})
+		if isError(result) {
+			return result
+		}
+		if !isTruthy(result) {
+			return FALSE
+		}
+	}
+
+	return TRUE
+}
+
+// builtinZip returns an array of arrays holding the elements at the same index in
+// each of its arguments, stopping at the end of the shortest one.
+func builtinZip(args ...object.Object) object.Object {
+	if len(args) == 0 {
+		return newError("wrong number of arguments. got=0, wanted at least 1")
+	}
+
+	arrays := make([]*object.Array, len(args))
+	length := -1
+	for i, arg := range args {
+		array, ok := arg.(*object.Array)
+		if !ok {
+			return newError("argument to `zip` not supported, got %s", arg.Type())
+		}
+
+		arrays[i] = array
+		if length == -1 || len(array.Elements) < length {
+			length = len(array.Elements)
+		}
+	}
+
+	elements := make([]object.Object, length)
+	for i := range elements {
+		tuple := make([]object.Object, len(arrays))
+		for j, array := range arrays {
+			tuple[j] = array.Elements[i]
+		}
+		elements[i] = &object.Array{Elements: tuple}
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+// builtinRange accepts (end), (start, end) or (start, end, step) and returns an
+// iterator over the integers from start (default 0) up to but not including end.
+func builtinRange(args ...object.Object) object.Object {
+	if len(args) < 1 || len(args) > 3 {
+		return newError("wrong number of arguments. got=%d, want=1 to 3", len(args))
+	}
+
+	bounds := make([]int64, len(args))
+	for i, arg := range args {
+		integer, ok := arg.(*object.Integer)
+		if !ok {
+			return newError("argument to `range` not supported, got %s", arg.Type())
+		}
+		bounds[i] = integer.Value
+	}
+
+	start, end, step := int64(0), bounds[0], int64(1)
+	if len(bounds) > 1 {
+		start, end = bounds[0], bounds[1]
+	}
+	if len(bounds) > 2 {
+		step = bounds[2]
+	}
+	if step == 0 {
+		return newError("`range` step cannot be 0")
+	}
+
+	i := start
+	return object.NewIterator("range", func() (object.Object, bool) {
+		if (step > 0 && i >= end) || (step < 0 && i <= end) {
+			return nil, false
+		}
+		i += step
+		return intToIntegerObject(i - step), true
+	}, nil)
+}
+
+func builtinReverse(args ...object.Object) object.Object {
+	if err := checkArgs("reverse", args, object.ARRAY_OBJ); err != nil {
+		return err
+	}
+
+	elements := args[0].(*object.Array).Elements
+	reversed := make([]object.Object, len(elements))
+	for i, element := range elements {
+		reversed[len(elements)-1-i] = element
+	}
+
+	return &object.Array{Elements: reversed}
+}
+
+// builtinFlatten removes one level of nesting from an array, leaving elements
+// that aren't arrays as they are.
+func builtinFlatten(args ...object.Object) object.Object {
+	if err := checkArgs("flatten", args, object.ARRAY_OBJ); err != nil {
+		return err
+	}
+
+	elements := make([]object.Object, 0)
+	for _, element := range args[0].(*object.Array).Elements {
+		if array, ok := element.(*object.Array); ok {
+			elements = append(elements, array.Elements...)
+		} else {
+			elements = append(elements, element)
+		}
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+// builtinUniq returns the elements of an array with duplicates (as compared by ==)
+// removed, keeping the first occurrence of each.
+func builtinUniq(args ...object.Object) object.Object {
+	if err := checkArgs("uniq", args, object.ARRAY_OBJ); err != nil {
+		return err
+	}
+
+	elements := make([]object.Object, 0)
+	seen := make(map[object.HashKey]bool)
+	for _, element := range args[0].(*object.Array).Elements {
+		if key, ok := object.HashKeyOf(element); ok {
+			if !seen[key] {
+				seen[key] = true
+				elements = append(elements, element)
+			}
+			continue
+		}
+
+		// Elements that can't be hashed are compared with the ones kept so far.
+		duplicate := false
+		for _, kept := range elements {
+			equal, err := object.Equal(element, kept)
+			if err != nil {
+				return err
+			}
+			if equal {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			elements = append(elements, element)
+		}
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+func arrayAndFunctionArgs(name string, args []object.Object) (*object.Array, object.Object, *object.Error) {
+	if len(args) != 2 {
+		return nil, nil, newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	array, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, nil, newError("argument to `%s` not supported, got %s", name, args[0].Type())
+	}
+	if !isCallable(args[1]) {
+		return nil, nil, newError("argument to `%s` not supported, got %s", name, args[1].Type())
+	}
+
+	return array, args[1], nil
+}
+
+func isCallable(obj object.Object) bool {
+	switch obj.(type) {
+	case *object.Function, *object.Builtin, *object.BoundMethod, *object.StructType, *object.Class,
+		*object.Variant:
+		return true
+	default:
+		return false
+	}
+}
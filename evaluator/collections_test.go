@@ -0,0 +1,50 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestCollectionBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`map([1, 2, 3], fn(x) { x * 2 })`, []interface{}{2, 4, 6}},
+		{`map([], fn(x) { x })`, []interface{}{}},
+		{`map(["a"], len)`, []interface{}{1}},
+		{`filter([1, 2, 3, 4], fn(x) { x > 2 })`, []interface{}{3, 4}},
+		{`reduce([1, 2, 3], 10, fn(total, x) { total + x })`, 16},
+		{`reduce([], 10, fn(total, x) { total + x })`, 10},
+		{`sort([3, 1, 2])`, []interface{}{1, 2, 3}},
+		{`sort(["b", "c", "a"])`, []interface{}{"a", "b", "c"}},
+		{`sort([3, 1, 2], fn(a, b) { a > b })`, []interface{}{3, 2, 1}},
+		{`let a = [2, 1]; sort(a); a`, []interface{}{2, 1}},
+		{`sort([[2, "b"], [1, "a"], [2, "a"]], fn(a, b) { a[0] < b[0] })`, []interface{}{[]interface{}{1, "a"}, []interface{}{2, "b"}, []interface{}{2, "a"}}},
+		{`find([1, 2, 3], fn(x) { x > 1 })`, 2},
+		{`find([1, 2, 3], fn(x) { x > 5 })`, nil},
+		{`[any([1, 2], fn(x) { x > 1 }), any([], fn(x) { true })]`, []interface{}{true, false}},
+		{`[all([1, 2], fn(x) { x > 1 }), all([], fn(x) { false })]`, []interface{}{false, true}},
+		{`zip([1, 2, 3], ["a", "b"])`, []interface{}{[]interface{}{1, "a"}, []interface{}{2, "b"}}},
+		{`map([range(3), range(1, 4), range(5, 0, -2), range(2, 1)], collect)`, []interface{}{[]interface{}{0, 1, 2}, []interface{}{1, 2, 3}, []interface{}{5, 3, 1}, []interface{}{}}},
+		{`range(3)`, inspected{object.ITERATOR_OBJ, "iterator range"}},
+		{`reverse([1, 2, 3])`, []interface{}{3, 2, 1}},
+		{`flatten([1, [2, 3], [[4]]])`, []interface{}{1, 2, 3, []interface{}{4}}},
+		{`uniq([1, 2, 1, "a", "a", true])`, []interface{}{1, 2, "a", true}},
+		{`map([1], fn(x, y) { x })`, expectedError("wrong number of arguments. got=1, want=2")},
+		{`map([1], 1)`, expectedError("argument to `map` not supported, got integer")},
+		{`filter([1], fn(x) { x + true })`, expectedError("type mismatch: integer + boolean")},
+		{`sort([1, "a"])`, expectedError("cannot compare STRING and integer without a comparator")},
+		{`sort([2, 1], fn(a, b) { a + true })`, expectedError("type mismatch: integer + boolean")},
+		{`range(1, 2, 0)`, expectedError("`range` step cannot be 0")},
+		{`uniq([[1], [1], {"a": [2]}, {"a": [2]}])`, []interface{}{[]interface{}{1}, expectedHash{"a": []interface{}{2}}}},
+		{`import { map } from "std/list"; map([1], fn(x) { x + 1 })`, []interface{}{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
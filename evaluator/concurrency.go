@@ -0,0 +1,197 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+	"reflect"
+	"time"
+)
+
+// Functions started with spawn run concurrently with the rest of the program.
+// Environments are safe to share between them, but the values they hold aren't:
+// arrays, hashes and other mutable values should only be modified by one task at
+// a time, with values passed between tasks through channels.
+
+func init() {
+	concurrencyBuiltins := map[string]*object.Builtin{
+		"channel": {Fn: builtinChannel},
+		"send":    {Fn: builtinSend},
+		"recv":    {Fn: builtinRecv},
+		"close":   {Fn: builtinClose},
+		"select":  {Fn: builtinSelect},
+		"await":   {Fn: builtinAwait},
+	}
+
+	for name, builtin := range concurrencyBuiltins {
+		builtins[name] = builtin
+	}
+}
+
+// evalSpawnExpression evaluates the function and arguments of a spawned call on
+// the current goroutine, then applies the function on a new one. It returns a
+// task that can be awaited for the result.
+func evalSpawnExpression(node *ast.SpawnExpression, env *object.Environment) object.Object {
+	var fn object.Object
+	args := []object.Object{}
+
+	if call, ok := node.Call.(*ast.CallExpression); ok && !call.Optional {
+		if fn = Eval(call.Function, env); isError(fn) {
+			return fn
+		}
+
+		if args = evalExpressions(call.Arguments, env); len(args) > 0 && isError(args[0]) {
+			return args[0]
+		}
+	} else if fn = Eval(node.Call, env); isError(fn) {
+		return fn
+	}
+
+	if !isCallable(fn) {
+		return newError("cannot spawn %s", fn.Type())
+	}
+
+	name := "<builtin>"
+	if function, ok := fn.(*object.Function); ok {
+		name = functionName(function)
+	}
+
+	task := object.NewTask(name)
+	go func() {
+		task.Finish(applyFunction(fn, args))
+	}()
+
+	return task
+}
+
+// builtinChannel creates a channel, which is unbuffered unless given a capacity.
+func builtinChannel(args ...object.Object) object.Object {
+	if len(args) == 0 {
+		return object.NewChannel(0)
+	}
+
+	if err := checkArgs("channel", args, object.INTEGER_OBJ); err != nil {
+		return err
+	}
+
+	capacity := args[0].(*object.Integer).Value
+	if capacity < 0 {
+		return newError("channel capacity cannot be negative, got %d", capacity)
+	}
+	return object.NewChannel(int(capacity))
+}
+
+func builtinSend(args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	channel, ok := args[0].(*object.Channel)
+	if !ok {
+		return newError("argument to `send` not supported, got %s", args[0].Type())
+	}
+
+	if !channel.Send(args[1]) {
+		return newError("send on closed channel")
+	}
+	return NULL
+}
+
+// builtinRecv returns the next value sent on a channel, or null once it's closed
+// and empty.
+func builtinRecv(args ...object.Object) object.Object {
+	if err := checkArgs("recv", args, object.CHANNEL_OBJ); err != nil {
+		return err
+	}
+
+	value, ok := args[0].(*object.Channel).Receive()
+	if !ok {
+		return NULL
+	}
+	return value
+}
+
+func builtinClose(args ...object.Object) object.Object {
+	if err := checkArgs("close", args, object.CHANNEL_OBJ); err != nil {
+		return err
+	}
+
+	args[0].(*object.Channel).Close()
+	return NULL
+}
+
+// builtinSelect waits for a value from any of an array of channels, returning a
+// tuple of the channel and the value (which is null if the channel was closed).
+// An optional timeout in milliseconds limits how long to wait, after which null
+// is returned; a timeout of 0 returns immediately if no channel is ready.
+func builtinSelect(args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return newError("wrong number of arguments. got=%d, want=1 or 2", len(args))
+	}
+
+	array, ok := args[0].(*object.Array)
+	if !ok {
+		return newError("argument to `select` not supported, got %s", args[0].Type())
+	}
+
+	// Each channel is waited on for a value and for being closed.
+	cases := make([]reflect.SelectCase, 0, len(array.Elements)*2+1)
+	channels := make([]*object.Channel, len(array.Elements))
+	for i, element := range array.Elements {
+		channel, ok := element.(*object.Channel)
+		if !ok {
+			return newError("argument to `select` not supported, got array of %s", element.Type())
+		}
+		channels[i] = channel
+
+		values, closed := channel.ReceiveChans()
+		cases = append(cases,
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(values)},
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(closed)},
+		)
+	}
+
+	if len(args) == 2 {
+		timeout, ok := args[1].(*object.Integer)
+		if !ok {
+			return newError("argument to `select` not supported, got %s", args[1].Type())
+		}
+
+		if timeout.Value <= 0 {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectDefault})
+		} else {
+			timer := time.NewTimer(time.Duration(timeout.Value) * time.Millisecond)
+			defer timer.Stop()
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+		}
+	}
+
+	chosen, received, _ := reflect.Select(cases)
+	if chosen >= len(channels)*2 {
+		return NULL
+	}
+
+	channel := channels[chosen/2]
+	if chosen%2 == 0 {
+		return &object.Tuple{Elements: []object.Object{channel, received.Interface().(object.Object)}}
+	}
+
+	value, ok := channel.Drain()
+	if !ok {
+		value = NULL
+	}
+	return &object.Tuple{Elements: []object.Object{channel, value}}
+}
+
+// builtinAwait waits for a spawned task to finish and returns its result, which
+// is an error if the task failed.
+func builtinAwait(args ...object.Object) object.Object {
+	if err := checkArgs("await", args, object.TASK_OBJ); err != nil {
+		return err
+	}
+
+	result := args[0].(*object.Task).Wait()
+	if result == nil {
+		return NULL
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestConcurrency(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let f = fn(a, b) { a + b }; let t = spawn f(1, 2); await(t)", 3},
+		{"let f = fn(a, b) { a + b }; spawn f(1, 2)", inspected{object.TASK_OBJ, "task f"}},
+		{"let t = spawn fn() { 5 }; t.await()", 5},
+		{"let t = spawn len([1, 2]); await(t)", 2},
+		{"let t = spawn fn() { throw \"oops\" }; await(t)", expectedError("oops")},
+		{"let t = spawn fn() { throw \"oops\" }; try { await(t) } catch (e) { e.message }", "oops"},
+		{"spawn 1", expectedError("cannot spawn integer")},
+		{"spawn f()", expectedError("identifier not found: f")},
+		{"channel()", inspected{object.CHANNEL_OBJ, "channel(0)"}},
+		{"let ch = channel(2); send(ch, 1); ch.send(2); [recv(ch), ch.recv()]", []interface{}{1, 2}},
+		{"let ch = channel(1); close(ch); ch", inspected{object.CHANNEL_OBJ, "channel(1, closed)"}},
+		{"let ch = channel(1); send(ch, 1); close(ch); [recv(ch), recv(ch)]", []interface{}{1, nil}},
+		{"let ch = channel(); close(ch); send(ch, 1)", expectedError("send on closed channel")},
+		{"let ch = channel(); ch.close(); ch.close(); recv(ch)", nil},
+		{"channel(-1)", expectedError("channel capacity cannot be negative, got -1")},
+		{
+			"let ch = channel(4); let produce = fn(n) { for (x in range(n)) { send(ch, x * x) }; close(ch) }; spawn produce(4); let f = fn() { for (x in ch) { if (x > 3) { return x } } }; f()",
+			4,
+		},
+		{
+			"let ch = channel(); spawn fn() { for (x in [1, 2, 3]) { send(ch, x) }; close(ch) }(); collect(ch)",
+			[]interface{}{1, 2, 3},
+		},
+		{
+			"let results = channel(3); let work = fn(n) { send(results, n * 10) }; let tasks = map([1, 2, 3], fn(n) { spawn work(n) }); map(tasks, await); sort([recv(results), recv(results), recv(results)])",
+			[]interface{}{10, 20, 30},
+		},
+		{"let a = channel(1); let b = channel(1); send(b, 2); let result = select([a, b]); [result[0] == b, result[1]]", []interface{}{true, 2}},
+		{"let a = channel(1); close(a); select([a])[1]", nil},
+		{"let a = channel(1); select([a], 0)", nil},
+		{"let a = channel(1); select([a], 10)", nil},
+		{"let a = channel(); spawn send(a, 7); select([a], 1000)[1]", 7},
+		{"select([1])", expectedError("argument to `select` not supported, got array of integer")},
+		{"recv(1)", expectedError("argument to `recv` not supported, got integer")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+// Spawned functions share their enclosing environment, so reading and defining
+// variables from several tasks at once must be safe.
+func TestSpawnSharesEnvironment(t *testing.T) {
+	evaluated := testEval(`
+		let done = channel(8);
+		let worker = fn(n) {
+			let total = reduce(collect(range(100)), 0, fn(a, b) { a + b + n * 0 });
+			send(done, total);
+		};
+		let tasks = map(collect(range(8)), fn(n) { spawn worker(n) });
+		map(tasks, await);
+		reduce(collect(range(8)), 0, fn(sum, _) { sum + recv(done) })
+	`)
+	if evaluated.Inspect() != "39600" {
+		t.Errorf("wrong result. want=%q, got=%q", "39600", evaluated.Inspect())
+	}
+}
+
+// Frames closed over by spawned functions can still be defined in by the task
+// that created them, so they must be locked once they're shared.
+func TestSpawnSharesFrames(t *testing.T) {
+	evaluated := testEval(`
+		let f = fn(n) {
+			let x = 1;
+			let tasks = map(collect(range(n)), fn(_) { spawn fn() { x > 0 }() });
+			let x = 2;
+			map(tasks, await)
+		};
+		f(8)
+	`)
+	if evaluated.Inspect() != "[true,true,true,true,true,true,true,true]" {
+		t.Errorf("wrong result. got=%q", evaluated.Inspect())
+	}
+}
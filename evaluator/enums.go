@@ -0,0 +1,104 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+func evalEnumStatement(node *ast.EnumStatement, env *object.Environment) object.Object {
+	enum := &object.Enum{Name: node.Name.Value}
+
+	for _, variant := range node.Variants {
+		var fields []string
+		if variant.Fields != nil {
+			fields = make([]string, len(variant.Fields))
+			for i, field := range variant.Fields {
+				fields[i] = field.Value
+			}
+		}
+
+		enum.Variants = append(enum.Variants, &object.Variant{Enum: enum, Name: variant.Name.Value, Fields: fields})
+	}
+
+	if err := define(env, enum.Name, enum); err != nil {
+		return err
+	}
+	return nil
+}
+
+// evalEnumMember returns the variant of enum called name, which is a constructor
+// if the variant has fields and a value otherwise.
+func evalEnumMember(enum *object.Enum, name string) object.Object {
+	variant, ok := enum.Variant(name)
+	if !ok {
+		return newError("%s has no variant %s", enum.Name, name)
+	}
+
+	if variant.Fields == nil {
+		return &object.EnumValue{Variant: variant}
+	}
+	return variant
+}
+
+func newEnumValue(variant *object.Variant, args []object.Object) object.Object {
+	if len(args) != len(variant.Fields) {
+		return newError("wrong number of arguments. got=%d, want=%d", len(args), len(variant.Fields))
+	}
+
+	values := make([]object.Object, len(args))
+	copy(values, args)
+
+	return &object.EnumValue{Variant: variant, Values: values}
+}
+
+func evalEnumValueField(value *object.EnumValue, name string) object.Object {
+	for i, field := range value.Variant.Fields {
+		if field == name {
+			return value.Values[i]
+		}
+	}
+
+	return newError("%s.%s has no field %s", value.Variant.Enum.Name, value.Variant.Name, name)
+}
+
+// matchConstructorPattern matches values built by the pattern's constructor (an
+// enum variant or a struct), binding their fields to the pattern's arguments in
+// the order they were declared.
+func matchConstructorPattern(pattern *ast.ConstructorPattern, value object.Object, env *object.Environment) (mismatch, err *object.Error) {
+	constructor := Eval(pattern.Constructor, env)
+	if isError(constructor) {
+		return nil, constructor.(*object.Error)
+	}
+
+	var fields []object.Object
+	switch constructor := constructor.(type) {
+	case *object.Variant:
+		enumValue, ok := value.(*object.EnumValue)
+		if !ok || enumValue.Variant != constructor {
+			return newError("%s does not match %s", value.Inspect(), pattern.String()), nil
+		}
+		fields = enumValue.Values
+	case *object.StructType:
+		instance, ok := value.(*object.Struct)
+		if !ok || instance.Definition != constructor {
+			return newError("%s does not match %s", value.Inspect(), pattern.String()), nil
+		}
+		for _, field := range constructor.Fields {
+			fields = append(fields, instance.Fields[field])
+		}
+	default:
+		return nil, newError("%s cannot be used as a constructor pattern", constructor.Type())
+	}
+
+	if len(fields) != len(pattern.Arguments) {
+		return nil, newError("cannot destructure %d fields into %d patterns", len(fields), len(pattern.Arguments))
+	}
+
+	for i, argument := range pattern.Arguments {
+		if mismatch, err := matchPattern(argument, fields[i], env); mismatch != nil || err != nil {
+			return mismatch, err
+		}
+	}
+
+	return nil, nil
+}
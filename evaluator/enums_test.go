@@ -0,0 +1,60 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestEnums(t *testing.T) {
+	shapes := `
+		enum Shape { Circle(r), Rect(w, h), Empty }
+		let area = fn(shape) {
+			match (shape) {
+				Shape.Circle(r) => 3 * r * r,
+				Shape.Rect(w, h) if w == h => "square",
+				Shape.Rect(w, h) => w * h,
+				Shape.Empty => 0,
+			}
+		};
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{shapes + "Shape", inspected{object.ENUM_OBJ, "enum Shape { Circle(r), Rect(w, h), Empty }"}},
+		{shapes + "Shape.Circle", inspected{object.VARIANT_OBJ, "variant Shape.Circle(r)"}},
+		{shapes + "Shape.Circle(2)", expectedEnumValue{"Shape.Circle", []interface{}{2}}},
+		{shapes + "[Shape.Rect(1, 2), Shape.Empty]", []interface{}{expectedEnumValue{"Shape.Rect", []interface{}{1, 2}}, expectedEnumValue{"Shape.Empty", nil}}},
+		{shapes + "Shape.Rect(1, 2).h", 2},
+		{shapes + "map([Shape.Circle(2), Shape.Rect(2, 3), Shape.Rect(2, 2), Shape.Empty], area)", []interface{}{12, 6, "square", 0}},
+		{shapes + "map([1, 2], Shape.Circle)", []interface{}{expectedEnumValue{"Shape.Circle", []interface{}{1}}, expectedEnumValue{"Shape.Circle", []interface{}{2}}}},
+		{shapes + "Shape.Circle(1) == Shape.Circle(1)", true},
+		{shapes + "Shape.Circle(1) == Shape.Circle(2)", false},
+		{shapes + "Shape.Empty == Shape.Empty", true},
+		{shapes + "enum Other { Empty }; Shape.Empty == Other.Empty", false},
+		{shapes + `let h = {Shape.Circle(1): "one"}; h[Shape.Circle(1)]`, "one"},
+		{shapes + "#{Shape.Empty, Shape.Empty, Shape.Circle([1])}", expectedSet{expectedEnumValue{"Shape.Empty", nil}, expectedEnumValue{"Shape.Circle", []interface{}{[]interface{}{1}}}}},
+		{shapes + "let Shape.Circle(r) = Shape.Circle(5); r", 5},
+		{shapes + "Shape.Triangle", expectedError("Shape has no variant Triangle")},
+		{shapes + "Shape.Circle(1).w", expectedError("Shape.Circle has no field w")},
+		{shapes + "Shape.Rect(1)", expectedError("wrong number of arguments. got=1, want=2")},
+		{shapes + "area(1)", expectedError("no match arm matched 1")},
+		{shapes + "let c = Shape.Circle(1); c.r = 2", expectedError("cannot assign to elements of ENUM_VALUE")},
+		{shapes + "{Shape.Circle({}): 1}", expectedError("object of type ENUM_VALUE cannot be used as a hash key")},
+		{"enum E { A() }; E.A()", expectedEnumValue{"E.A", []interface{}{}}},
+		{"struct P { x, y }; match (P(1, 2)) { P(x, y) => x + y }", 3},
+		{"struct P { x, y }; struct Q { x, y }; match (Q(1, 2)) { P(x, y) => 1, Q(_, y) => y }", 2},
+		{"let f = fn(x) { x }; match (1) { f(x) => x }", expectedError("function cannot be used as a constructor pattern")},
+		{shapes + "match (Shape.Empty) { Shape.Circle(r, x) => 1, _ => 2 }", 2},
+		{shapes + "match (Shape.Circle(1)) { Shape.Circle(r, x) => 1, _ => 2 }", expectedError("cannot destructure 1 fields into 2 patterns")},
+		{shapes + "match (Shape.Empty) { Shape.Square(s) => s, _ => 0 }", expectedError("Shape has no variant Square")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+// Kinds of errors exposed to scripts through the `kind` field of caught errors.
+const (
+	RUNTIME_ERROR = "RuntimeError"
+	THROWN_ERROR  = "Error"
+)
+
+func evalThrowStatement(ts *ast.ThrowStatement, env *object.Environment) object.Object {
+	val := Eval(ts.Value, env)
+	if isError(val) {
+		return val
+	}
+
+	err := &object.Error{Message: val.Inspect(), Kind: THROWN_ERROR, Value: val}
+
+	// Throwing a hash (such as a previously caught error) lets scripts set the
+	// message and kind themselves.
+	if hash, ok := val.(*object.Hash); ok {
+		if message, ok := hashStringValue(hash, "message"); ok {
+			err.Message = message
+		}
+		if kind, ok := hashStringValue(hash, "kind"); ok {
+			err.Kind = kind
+		}
+		if value, ok := hash.Pairs[(&object.String{Value: "value"}).HashKey()]; ok {
+			err.Value = value.Value
+		}
+	}
+
+	return err
+}
+
+// evalTryExpression evaluates the try, catch and finally blocks in scopes of their
+// own, so names bound in them aren't visible after the try expression.
+func evalTryExpression(te *ast.TryExpression, env *object.Environment) object.Object {
+	result := Eval(te.Body, object.NewFrame(env, te.BodyLocals))
+
+	if err, ok := result.(*object.Error); ok && err != errGeneratorStopped && te.Catch != nil {
+		catchEnv := object.NewFrame(env, te.CatchLocals)
+
+		if te.CatchParam != nil {
+			if bindErr := bindPattern(te.CatchParam, errorToHash(err), catchEnv); bindErr != nil {
+				return bindErr
+			}
+		}
+
+		result = Eval(te.Catch, catchEnv)
+	}
+
+	// Errors and returns from the finally block take precedence over the result
+	// of the try or catch blocks.
+	if te.Finally != nil {
+		finally := Eval(te.Finally, object.NewFrame(env, te.FinallyLocals))
+		if finally != nil {
+			if rt := finally.Type(); rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+				return finally
+			}
+		}
+	}
+
+	if result == nil {
+		return NULL
+	}
+	return result
+}
+
+// errorToHash converts err into the hash bound to the parameter of a catch block.
+func errorToHash(err *object.Error) *object.Hash {
+	stack := make([]object.Object, 0, len(err.Stack))
+	for _, frame := range err.Stack {
+		stack = append(stack, &object.String{Value: frame})
+	}
+
+	value := err.Value
+	if value == nil {
+		value = NULL
+	}
+
+	fields := []struct {
+		name  string
+		value object.Object
+	}{
+		{"message", &object.String{Value: err.Message}},
+		{"kind", &object.String{Value: err.Kind}},
+		{"stack", &object.Array{Elements: stack}},
+		{"value", value},
+	}
+
+	hash := object.NewHash()
+	for _, field := range fields {
+		key := &object.String{Value: field.name}
+		hash.Set(key.HashKey(), object.HashPair{Key: key, Value: field.value})
+	}
+
+	return hash
+}
+
+func hashStringValue(hash *object.Hash, key string) (string, bool) {
+	pair, ok := hash.Pairs[(&object.String{Value: key}).HashKey()]
+	if !ok {
+		return "", false
+	}
+
+	str, ok := pair.Value.(*object.String)
+	if !ok {
+		return "", false
+	}
+	return str.Value, true
+}
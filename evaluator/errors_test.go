@@ -0,0 +1,81 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestTryExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`try { 1 } catch (e) { 2 }`, 1},
+		{`try { -true } catch (e) { 2 }`, 2},
+		{`try { throw "boom"; 1 } catch (e) { e["message"] }`, "boom"},
+		{`try { throw "boom" } catch (e) { e["kind"] }`, "Error"},
+		{`try { throw 5 } catch (e) { e["value"] + 1 }`, 6},
+		{`try { 5 + true } catch (e) { e["message"] }`, "type mismatch: integer + boolean"},
+		{`try { 5 + true } catch (e) { e["kind"] }`, "RuntimeError"},
+		{`try { throw {"kind": "NotFound", "message": "no user"} } catch (e) { e["kind"] + ": " + e["message"] }`, "NotFound: no user"},
+		{`try { throw "boom" } catch ({message}) { message }`, "boom"},
+		{`try { throw "boom" } catch { "handled" }`, "handled"},
+		{`let config = {}; let port = try { config["db"]["port"] } catch (e) { 5432 }; port`, 5432},
+		{`let x = 1; try { let x = 2; x } catch (e) { 0 }`, 2},
+		{`let x = 1; try { let x = 2 } catch (e) { 0 }; x`, 1},
+		{`let f = fn() { try { let y = 1 } catch (e) { 0 }; y }; f()`, expectedError("identifier not found: y")},
+		{`let x = 1; try { 1 } finally { let x = 2 }; x`, 1},
+		{`let f = fn() { try { 1 } finally { let y = 2 }; y }; f()`, expectedError("identifier not found: y")},
+		{`try { throw "a" } catch (e) { throw "b" } finally { 1 }`, expectedError("b")},
+		{`try { 1 } finally { throw "finally" }`, expectedError("finally")},
+		{`try { try { throw "inner" } finally { 1 } } catch (e) { e["message"] }`, "inner"},
+		{`try { try { throw "inner" } catch (e) { throw e } } catch (e) { e["message"] }`, "inner"},
+		{`let f = fn() { try { return 1; } finally { 2 }; 3 }; f()`, 1},
+		{`let f = fn() { try { return 1; } finally { return 2; } }; f()`, 2},
+		{`let f = fn() { try { throw "x" } catch (e) { return 10; }; 3 }; f()`, 10},
+		{`throw "uncaught"; 5`, expectedError("uncaught")},
+		{`try { 1 } catch (e) { }`, 1},
+		{`try { } catch (e) { 1 }`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			testObject(t, testEval(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestErrorStack(t *testing.T) {
+	input := `
+		let inner = fn() { throw "boom" };
+		let outer = fn() { inner() };
+		try { outer() } catch (e) { e["stack"] }
+	`
+
+	evaluated := testEval(input)
+	stack, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	expected := []string{"inner", "outer"}
+	if len(stack.Elements) != len(expected) {
+		t.Fatalf("stack has wrong length. want=%d, got=%d (%s)", len(expected), len(stack.Elements), stack.Inspect())
+	}
+
+	for i, frame := range expected {
+		if stack.Elements[i].Inspect() != frame {
+			t.Errorf("wrong stack frame %d. want=%q, got=%q", i, frame, stack.Elements[i].Inspect())
+		}
+	}
+
+	evaluated = testEval(`fn() { throw "anonymous" }()`)
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if len(errObj.Stack) != 1 || errObj.Stack[0] != "<anonymous>" {
+		t.Errorf("wrong stack for anonymous function. got=%v", errObj.Stack)
+	}
+}
@@ -16,6 +16,11 @@ var (
 func Eval(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	case *ast.AST:
+		// Programs can define names anywhere in the environment they're
+		// evaluated in, so they must be given an environment of their own.
+		if env.Frozen() {
+			return newError("cannot evaluate a program in a frozen environment")
+		}
 		return evalProgram(node.Statements, env)
 	case *ast.ExpressionStatement:
 		return Eval(node.Expression, env)
@@ -25,6 +30,8 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return boolToBooleanObject(node.Value)
 	case *ast.String:
 		return &object.String{Value: node.Value}
+	case *ast.Null:
+		return NULL
 	case *ast.Array:
 		expressions := evalExpressions(node.Elements, env)
 
@@ -32,6 +39,15 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			return expressions[0]
 		}
 		return &object.Array{Elements: expressions}
+	case *ast.Tuple:
+		expressions := evalExpressions(node.Elements, env)
+
+		if len(expressions) > 0 && isError(expressions[0]) {
+			return expressions[0]
+		}
+		return &object.Tuple{Elements: expressions}
+	case *ast.Set:
+		return evalSetLiteral(node, env)
 	case *ast.PrefixExpression:
 		right := Eval(node.Right, env)
 		if isError(right) {
@@ -43,6 +59,15 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(left) {
 			return left
 		}
+
+		// The right side of ?? is only evaluated if it's needed.
+		if node.Operator == "??" {
+			if left != NULL {
+				return left
+			}
+			return Eval(node.Right, env)
+		}
+
 		right := Eval(node.Right, env)
 		if isError(right) {
 			return right
@@ -51,8 +76,36 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalInfixExpression(node.Operator, left, right)
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+	case *ast.ConditionalExpression:
+		condition := Eval(node.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+
+		if isTruthy(condition) {
+			return Eval(node.Consequence, env)
+		}
+		return Eval(node.Alternative, env)
 	case *ast.BlockStatement:
 		return evalBlockStatement(node, env)
+	case *ast.ImportStatement:
+		return evalImportStatement(node, env)
+	case *ast.StructStatement:
+		return evalStructStatement(node, env)
+	case *ast.ClassStatement:
+		return evalClassStatement(node, env)
+	case *ast.EnumStatement:
+		return evalEnumStatement(node, env)
+	case *ast.ExportStatement:
+		return Eval(node.Statement, env)
+	case *ast.ThrowStatement:
+		return evalThrowStatement(node, env)
+	case *ast.TryExpression:
+		return evalTryExpression(node, env)
+	case *ast.ForExpression:
+		return evalForExpression(node, env)
 	case *ast.ReturnStatement:
 		val := Eval(node.Value, env)
 		if isError(val) {
@@ -64,42 +117,137 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(val) {
 			return val
 		}
-		env.Set(node.Name.Value, val)
+		if err := bindPattern(node.Name, val, env); err != nil {
+			return err
+		}
+
+		// Name functions after the first identifier they're bound to so that
+		// they can be identified in stack traces.
+		if fn, ok := val.(*object.Function); ok && fn.Name == "" {
+			if name, ok := node.Name.(*ast.Identifier); ok {
+				fn.Name = name.Value
+			}
+		}
 	case *ast.Identifier:
 		return evalIdentifier(node, env)
 	case *ast.Function:
+		env.Share()
 		return &object.Function{
 			Parameters: node.Parameters,
 			Body:       node.Body,
 			Env:        env,
+			Generator:  node.Generator,
+			Locals:     node.Locals,
 		}
+	case *ast.YieldExpression:
+		return evalYieldExpression(node, env)
+	case *ast.SpawnExpression:
+		return evalSpawnExpression(node, env)
+	case *ast.MacroLiteral:
+		return newError("macros can only be defined by top-level let statements")
 	case *ast.CallExpression:
-		fn := Eval(node.Function, env)
-		if isError(fn) {
-			return fn
-		}
-
-		args := evalExpressions(node.Arguments, env)
-		if len(args) > 0 && isError(args[0]) {
-			return args[0]
-		}
+		result, _ := evalCallExpression(node, env)
+		return result
+	case *ast.IndexExpression:
+		result, _ := evalIndexChain(node, env)
+		return result
+	case *ast.SliceExpression:
+		result, _ := evalSliceExpression(node, env)
+		return result
+	case *ast.MemberExpression:
+		result, _ := evalMemberChain(node, env)
+		return result
+	case *ast.AssignExpression:
+		return evalAssignExpression(node, env)
+	case *ast.Hash:
+		return evalHashLiteral(node, env)
+	}
+	return nil
+}
 
-		return applyFunction(fn, args)
+// evalChain evaluates a link in a chain of calls, indexes, slices and member
+// accesses, reporting whether an optional link (`?.` or `?[`) short-circuited the
+// chain. Any other expression is evaluated as the head of a chain.
+func evalChain(node ast.Expression, env *object.Environment) (object.Object, bool) {
+	switch node := node.(type) {
+	case *ast.CallExpression:
+		return evalCallExpression(node, env)
 	case *ast.IndexExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
-			return left
-		}
+		return evalIndexChain(node, env)
+	case *ast.SliceExpression:
+		return evalSliceExpression(node, env)
+	case *ast.MemberExpression:
+		return evalMemberChain(node, env)
+	}
+	return Eval(node, env), false
+}
+
+// evalChainTarget evaluates what a link of a chain is applied to. The rest of the
+// chain is skipped if an earlier link short-circuited or if this link is optional
+// and its target is null, so `null?.a.b` is null rather than an error.
+func evalChainTarget(target ast.Expression, optional bool, env *object.Environment) (object.Object, bool) {
+	obj, skipped := evalChain(target, env)
+	if skipped || (optional && obj == NULL) {
+		return NULL, true
+	}
+	return obj, false
+}
 
-		index := Eval(node.Index, env)
-		if isError(index) {
-			return index
+func evalCallExpression(node *ast.CallExpression, env *object.Environment) (object.Object, bool) {
+	if identifier, ok := node.Function.(*ast.Identifier); ok && identifier.Value == "quote" {
+		if len(node.Arguments) != 1 {
+			return newError("wrong number of arguments to `quote`. got=%d, want=1", len(node.Arguments)), false
 		}
+		return quote(node.Arguments[0], env), false
+	}
 
-		return evalIndexExpression(left, index)
-	case *ast.Hash:
-		return evalHashLiteral(node, env)
+	fn, skipped := evalChainTarget(node.Function, node.Optional, env)
+	if skipped || isError(fn) {
+		return fn, skipped
+	}
+
+	args := evalExpressions(node.Arguments, env)
+	if len(args) > 0 && isError(args[0]) {
+		return args[0], false
+	}
+
+	if function, ok := fn.(*object.Function); ok && node.Tail && !function.Generator {
+		return &object.TailCall{Function: function, Arguments: args}, false
+	}
+	return applyFunction(fn, args), false
+}
+
+func evalIndexChain(node *ast.IndexExpression, env *object.Environment) (object.Object, bool) {
+	left, skipped := evalChainTarget(node.Left, node.Optional, env)
+	if skipped || isError(left) {
+		return left, skipped
 	}
+
+	index := Eval(node.Index, env)
+	if isError(index) {
+		return index, false
+	}
+
+	return evalIndexExpression(left, index), false
+}
+
+func evalMemberChain(node *ast.MemberExpression, env *object.Environment) (object.Object, bool) {
+	obj, skipped := evalChainTarget(node.Object, node.Optional, env)
+	if skipped || isError(obj) {
+		return obj, skipped
+	}
+
+	return evalMemberExpression(obj, node.Property), false
+}
+
+// define binds name to value in env. Frozen environments can be shared by
+// concurrent scripts, so names can't be defined in them.
+func define(env *object.Environment, name string, value object.Object) *object.Error {
+	if env.Frozen() {
+		return newError("cannot define %s in a frozen environment", name)
+	}
+
+	env.Set(name, value)
 	return nil
 }
 
@@ -114,11 +262,13 @@ func evalProgram(stmts []ast.Statement, env *object.Environment) object.Object {
 	var result object.Object
 
 	for _, stmt := range stmts {
-		result = Eval(stmt, env)
+		// Only calls in function bodies are marked as tail calls, but a
+		// TailCall must never be the result of a statement in a program.
+		result = finishTailCall(Eval(stmt, env))
 
 		switch r := result.(type) {
 		case *object.Return:
-			return r.Value
+			return finishTailCall(r.Value)
 		case *object.Error:
 			return r
 		}
@@ -127,6 +277,15 @@ func evalProgram(stmts []ast.Statement, env *object.Environment) object.Object {
 	return result
 }
 
+// finishTailCall makes the call if obj is a TailCall that wasn't made by
+// applyFunction.
+func finishTailCall(obj object.Object) object.Object {
+	if tailCall, ok := obj.(*object.TailCall); ok {
+		return applyFunction(tailCall.Function, tailCall.Arguments)
+	}
+	return obj
+}
+
 func evalPrefixExpression(operator string, right object.Object) object.Object {
 	switch operator {
 	case "!":
@@ -152,6 +311,10 @@ func evalBangOperatorExpression(right object.Object) object.Object {
 }
 
 func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
+	if result, ok := callSpecialMethod(right, "__neg__"); ok {
+		return result
+	}
+
 	if right.Type() != object.INTEGER_OBJ {
 		return newError("unknown operator: -%s", right.Type())
 	}
@@ -165,15 +328,21 @@ func evalInfixExpression(
 	left object.Object,
 	right object.Object,
 ) object.Object {
+	if result, ok := evalOperatorMethod(operator, left, right); ok {
+		return result
+	}
+
 	switch {
+	case operator == "in":
+		return evalInExpression(left, right)
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
 		return evalIntegerInfixExpression(operator, left, right)
+	case left.Type() == object.SET_OBJ && right.Type() == object.SET_OBJ:
+		return evalSetInfixExpression(operator, left, right)
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
 		return evalStringInfixExpression(operator, left, right)
-	case operator == "==":
-		return boolToBooleanObject(left == right)
-	case operator == "!=":
-		return boolToBooleanObject(left != right)
+	case operator == "==" || operator == "!=":
+		return evalEqualityExpression(operator, left, right)
 	case left.Type() != right.Type():
 		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
 	default:
@@ -215,13 +384,23 @@ func evalStringInfixExpression(operator string,
 	left object.Object,
 	right object.Object,
 ) object.Object {
-	if operator != "+" {
-		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
-	}
-
 	leftVal := left.(*object.String).Value
 	rightVal := right.(*object.String).Value
-	return &object.String{Value: leftVal + rightVal}
+
+	switch operator {
+	case "+":
+		return &object.String{Value: leftVal + rightVal}
+	case "<":
+		return boolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return boolToBooleanObject(leftVal > rightVal)
+	case "==":
+		return boolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return boolToBooleanObject(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
 }
 
 func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
@@ -232,6 +411,8 @@ func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Obje
 
 	if isTruthy(condition) {
 		return Eval(ie.Consequence, env)
+	} else if ie.ElseIf != nil {
+		return evalIfExpression(ie.ElseIf, env)
 	} else if ie.Alternative != nil {
 		return Eval(ie.Alternative, env)
 	}
@@ -267,6 +448,14 @@ func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) obje
 }
 
 func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
+	// Local variables that haven't been set yet (such as on the right of `let x
+	// = x`) fall back to being looked up by name in the enclosing scopes.
+	if node.Resolved {
+		if val, ok := env.GetAt(node.Depth, node.Slot); ok {
+			return val
+		}
+	}
+
 	if val, ok := env.Get(node.Value); ok {
 		return val
 	}
@@ -296,16 +485,77 @@ func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Ob
 func applyFunction(fn object.Object, args []object.Object) object.Object {
 	switch fn := fn.(type) {
 	case *object.Function:
-		extendedEnv := extendFunctionEnv(fn, args)
-		evaluated := Eval(fn.Body, extendedEnv)
-		return unwrapReturnValue(evaluated)
+		if fn.Generator {
+			return newGenerator(fn, args)
+		}
+
+		// Calls in tail position evaluate to a TailCall rather than being made
+		// where they appear, and are made here once the calling function has
+		// returned so that recursion in tail position uses constant stack space.
+		// The functions that made them are kept for stack traces, with each
+		// function only listed once (where it was last called) however many
+		// times it's called, so that loops and mutual recursion don't grow it.
+		var callers []string
+		for {
+			extendedEnv, err := extendFunctionEnv(fn, args)
+			if err != nil {
+				return addCallers(err, callers)
+			}
+			evaluated := unwrapReturnValue(Eval(fn.Body, extendedEnv))
+
+			if tailCall, ok := evaluated.(*object.TailCall); ok {
+				callers = appendCaller(callers, functionName(fn))
+				fn, args = tailCall.Function, tailCall.Arguments
+				continue
+			}
+
+			if err, ok := evaluated.(*object.Error); ok {
+				return addCallers(err, appendCaller(callers, functionName(fn)))
+			}
+			return evaluated
+		}
 	case *object.Builtin:
 		return fn.Fn(args...)
+	case *object.BoundMethod:
+		return applyFunction(fn.Method, append([]object.Object{fn.Receiver}, args...))
+	case *object.StructType:
+		return newStruct(fn, args)
+	case *object.Class:
+		return newInstance(fn, args)
+	case *object.Variant:
+		return newEnumValue(fn, args)
 	default:
 		return newError("not a function: %s", fn.Type())
 	}
 }
 
+// appendCaller adds name to the end of callers, removing any earlier entry for
+// it.
+func appendCaller(callers []string, name string) []string {
+	for i, caller := range callers {
+		if caller == name {
+			callers = append(callers[:i], callers[i+1:]...)
+			break
+		}
+	}
+	return append(callers, name)
+}
+
+// addCallers adds callers to the stack of err, starting with the innermost.
+func addCallers(err *object.Error, callers []string) *object.Error {
+	for i := len(callers) - 1; i >= 0; i-- {
+		err.Stack = append(err.Stack, callers[i])
+	}
+	return err
+}
+
+func functionName(fn *object.Function) string {
+	if fn.Name == "" {
+		return "<anonymous>"
+	}
+	return fn.Name
+}
+
 func unwrapReturnValue(obj object.Object) object.Object {
 	if returnValue, ok := obj.(*object.Return); ok {
 		return returnValue.Value
@@ -314,22 +564,39 @@ func unwrapReturnValue(obj object.Object) object.Object {
 	return obj
 }
 
-func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
-	env := object.NewEnclosingEnvironment(fn.Env)
+func extendFunctionEnv(fn *object.Function, args []object.Object) (*object.Environment, *object.Error) {
+	if len(args) < len(fn.Parameters) {
+		return nil, newError("wrong number of arguments. got=%d, want=%d", len(args), len(fn.Parameters))
+	}
+
+	env := object.NewFrame(fn.Env, fn.Locals)
 
 	for paramIdx, param := range fn.Parameters {
-		env.Set(param.Value, args[paramIdx])
+		if err := bindPattern(param, args[paramIdx], env); err != nil {
+			return nil, err
+		}
 	}
 
-	return env
+	return env, nil
 }
 
 func evalIndexExpression(left, index object.Object) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
 		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.STRING_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalStringIndexExpression(left, index)
+	case left.Type() == object.TUPLE_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalTupleIndexExpression(left, index)
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpression(left, index)
+	case left.Type() == object.MODULE_OBJ && index.Type() == object.STRING_OBJ:
+		return evalModuleMember(left.(*object.Module), index.(*object.String).Value)
+	case left.Type() == object.INSTANCE_OBJ:
+		if result, ok := callSpecialMethod(left, "__index__", index); ok {
+			return result
+		}
+		return newError("index operator not supported: %s", left.Type())
 	default:
 		return newError("index operator not supported: %s", left.Type())
 	}
@@ -339,22 +606,23 @@ func evalArrayIndexExpression(array, index object.Object) object.Object {
 	idx := index.(*object.Integer).Value
 	elements := array.(*object.Array).Elements
 
-	if idx < 0 || idx > int64(len(elements))-1 {
+	i, ok := resolveIndex(idx, len(elements))
+	if !ok {
 		return newError(fmt.Sprintf("index %d exceeds bounds of array of length %d", idx, len(elements)))
 	}
 
-	return elements[idx]
+	return elements[i]
 }
 
 func evalHashIndexExpression(hash, index object.Object) object.Object {
 	hashObject := hash.(*object.Hash)
 
-	key, ok := index.(object.Hashable)
+	key, ok := object.HashKeyOf(index)
 	if !ok {
 		return newError("index is not a valid hash key (type: %s)", index.Type())
 	}
 
-	pair, ok := hashObject.Pairs[key.HashKey()]
+	pair, ok := hashObject.Pairs[key]
 	if !ok {
 		return NULL
 	}
@@ -362,34 +630,76 @@ func evalHashIndexExpression(hash, index object.Object) object.Object {
 	return pair.Value
 }
 
+func evalMemberExpression(obj object.Object, property *ast.Identifier) object.Object {
+	if method, ok := lookupMethod(obj, property.Value); ok {
+		return method
+	}
+
+	switch obj := obj.(type) {
+	case *object.Struct:
+		return evalStructField(obj, property.Value)
+	case *object.Instance:
+		return evalInstanceMember(obj, property.Value)
+	case *object.Super:
+		return evalSuperMember(obj, property.Value)
+	case *object.Enum:
+		return evalEnumMember(obj, property.Value)
+	case *object.EnumValue:
+		return evalEnumValueField(obj, property.Value)
+	case *object.Hash:
+		return evalHashIndexExpression(obj, &object.String{Value: property.Value})
+	case *object.Module:
+		return evalModuleMember(obj, property.Value)
+	default:
+		return newError("member access not supported: %s.%s", obj.Type(), property.Value)
+	}
+}
+
+func evalModuleMember(module *object.Module, name string) object.Object {
+	value, ok := module.Exports[name]
+	if !ok {
+		return newError("module %q has no export %s", module.Path, name)
+	}
+	return value
+}
+
+// evalEqualityExpression compares left and right with object.Equal, for `==` and
+// `!=` between values that don't have operators of their own.
+func evalEqualityExpression(operator string, left, right object.Object) object.Object {
+	equal, err := object.Equal(left, right)
+	if err != nil {
+		return err
+	}
+	return boolToBooleanObject(equal == (operator == "=="))
+}
+
 func evalHashLiteral(node *ast.Hash, env *object.Environment) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
+	hash := object.NewHash()
 
-	for keyNode, valueNode := range node.Pairs {
-		key := Eval(keyNode, env)
+	for _, pair := range node.Pairs {
+		key := Eval(pair.Key, env)
 		if isError(key) {
 			return key
 		}
 
-		hashKey, ok := key.(object.Hashable)
+		hashKey, ok := object.HashKeyOf(key)
 		if !ok {
-			return newError("object of type %T cannot be used as a hash key", hashKey)
+			return newError("object of type %s cannot be used as a hash key", key.Type())
 		}
 
-		value := Eval(valueNode, env)
+		value := Eval(pair.Value, env)
 		if isError(value) {
 			return value
 		}
 
-		hashedKey := hashKey.HashKey()
-		pairs[hashedKey] = object.HashPair{Key: key, Value: value}
+		hash.Set(hashKey, object.HashPair{Key: key, Value: value})
 	}
 
-	return &object.Hash{Pairs: pairs}
+	return hash
 }
 
 func newError(format string, a ...interface{}) *object.Error {
-	return &object.Error{Message: fmt.Sprintf(format, a...)}
+	return &object.Error{Message: fmt.Sprintf(format, a...), Kind: RUNTIME_ERROR}
 }
 
 func intToIntegerObject(val int64) *object.Integer {
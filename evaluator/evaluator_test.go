@@ -7,10 +7,16 @@ import (
 	"testing"
 )
 
+// testEval resolves and evaluates input. Errors from the resolver are returned
+// instead of evaluating the program, as the REPL and imports do.
 func testEval(input string) object.Object {
 	p := parser.New(lexer.New(input))
 	program := p.ParseProgram()
-	return Eval(program, object.NewEnvironment())
+	env := object.NewEnvironment()
+	if errs := Resolve(program, env); len(errs) != 0 {
+		return newError("%s", joinErrors(errs))
+	}
+	return Eval(program, env)
 }
 
 func TestEvalIntegerExpression(t *testing.T) {
@@ -135,6 +141,10 @@ func TestIfElseExpressions(t *testing.T) {
 		{"if (1 > 2) { 10 }", nil},
 		{"if (1 > 2) { 10 } else { 20 }", 20},
 		{"if (1 < 2) { 10 } else { 20 }", 10},
+		{"if (1 > 2) { 10 } else if (2 > 1) { 20 } else { 30 }", 20},
+		{"if (1 > 2) { 10 } else if (2 > 3) { 20 } else { 30 }", 30},
+		{"if (1 > 2) { 10 } else if (2 > 3) { 20 }", nil},
+		{"if (false) { 1 } else if (false) { 2 } else if (true) { 3 } else { 4 }", 3},
 	}
 
 	for _, tt := range tests {
@@ -152,6 +162,29 @@ func TestIfElseExpressions(t *testing.T) {
 	}
 }
 
+func TestConditionalExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"true ? 1 : 2", 1},
+		{"false ? 1 : 2", 2},
+		{"null ? 1 : 2", 2},
+		{"1 < 2 ? 10 + 1 : 20", 11},
+		{"let x = 5; x > 3 ? x > 4 ? 1 : 2 : 3", 1},
+		{"let x = 0; x > 3 ? 1 : x < 0 ? 2 : 3", 3},
+		{"let x = null; x ?? 4 > 3 ? 1 : 2", 1},
+		{"true ? 1 : -true", 1},
+		{"let c = false; (c ?[10]:[20])[0]", 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			testIntegerObject(t, testEval(tt.input), tt.expected)
+		})
+	}
+}
+
 func TestReturnStatements(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -285,6 +318,7 @@ func TestBuiltinFunctions(t *testing.T) {
 		{`len("")`, 0},
 		{`len("four")`, 4},
 		{`len("hello world")`, 11},
+		{`len("héllo wörld")`, 11},
 		{`len(1)`, "argument to `len` not supported, got integer"},
 		{`len("one", "two")`, "wrong number of arguments. got=2, want=1"},
 	}
@@ -342,7 +376,9 @@ func TestArrayIndexExpressions(t *testing.T) {
 		{"let myArray = [1, 2, 3]; myArray[0] + myArray[1] + myArray[2];", 6},
 		{"let myArray = [1, 2, 3]; let i = myArray[0]; myArray[i]", 2},
 		{"[1, 2, 3][3]", "index 3 exceeds bounds of array of length 3"},
-		{"[1, 2, 3][-1]", "index -1 exceeds bounds of array of length 3"},
+		{"[1, 2, 3][-1]", 3},
+		{"[1, 2, 3][-3]", 1},
+		{"[1, 2, 3][-4]", "index -4 exceeds bounds of array of length 3"},
 	}
 
 	for _, tt := range tests {
@@ -393,6 +429,77 @@ func TestHashLiterals(t *testing.T) {
 	}
 }
 
+func TestHashLiteralOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"c": 1, "a": 2, "b": 3, 1: 4, true: 5}`, "{c:1, a:2, b:3, 1:4, true:5}"},
+		{`keys({"z": 1, "y": 2, "x": 3})`, "[z,y,x]"},
+		{`{"a": 1, "b": 2, "a": 3}`, "{a:3, b:2}"},
+		// keys and values are evaluated in source order, so the first error wins.
+		{`{"a": 1 + true, "b": "x" - "y"}`, "ERROR: type mismatch: integer + boolean"},
+		{`{"a": 1, -true: 2}`, "ERROR: unknown operator: -boolean"},
+	}
+
+	for _, tt := range tests {
+		// Go randomises map iteration, so evaluate each input a few times.
+		for i := 0; i < 10; i++ {
+			if evaluated := testEval(tt.input); evaluated.Inspect() != tt.expected {
+				t.Fatalf("wrong result for %q. want=%q, got=%q", tt.input, tt.expected, evaluated.Inspect())
+			}
+		}
+	}
+}
+
+func TestStructuralEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"[1, 2] == [1, 2]", true},
+		{"[1, 2] != [1, 2]", false},
+		{"[1, 2] == [2, 1]", false},
+		{"[1, [2, 3]] == [1, [2, 3]]", true},
+		{`[1, "a", true, null] == [1, "a", true, null]`, true},
+		{`{"a": 1, "b": [2]} == {"b": [2], "a": 1}`, true},
+		{`{"a": 1} == {"a": 2}`, false},
+		{`{"a": 1} != {"a": 1, "b": 2}`, true},
+		{`[] == {}`, false},
+		{`1 == "1"`, false},
+		{"let f = fn() { 1 }; f == f", true},
+		{"fn() { 1 } == fn() { 1 }", false},
+		{`match ([1, [2]]) { [1, [2]] => true, _ => false }`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			testBooleanObject(t, testEval(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestArrayHashKeys(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`{[1, 2]: "a"}[[1, 2]]`, "a"},
+		{`let key = [1, [2, "x"]]; {key: "a"}[[1, [2, "x"]]]`, "a"},
+		{`{[1, 2]: "a"}[[2, 1]]`, nil},
+		{`{[1, 2]: "a", [1, 2]: "b"}`, inspected{object.HASH_OBJ, "{[1,2]:b}"}},
+		{`has({[1]: 1}, [1])`, true},
+		{`{[{}]: 1}`, expectedError("object of type ARRAY cannot be used as a hash key")},
+		{`{"a": 1}[[fn() {}]]`, expectedError("index is not a valid hash key (type: ARRAY)")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			testObject(t, testEval(tt.input), tt.expected)
+		})
+	}
+}
+
 func TestHashIndexExpressions(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -419,6 +526,85 @@ func TestHashIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestNullCoalescing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"null", nil},
+		{"null ?? 5", 5},
+		{"3 ?? 5", 3},
+		{"false ?? 5", false},
+		{"null ?? null", nil},
+		{`{"a": 1}["b"] ?? 2`, 2},
+		{"null ?? null ?? 7", 7},
+		{"1 ?? -true", 1},
+		{"null == null", true},
+		{`{"a": 1}["b"] == null`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+
+			switch expected := tt.expected.(type) {
+			case int:
+				testIntegerObject(t, evaluated, int64(expected))
+			case bool:
+				testBooleanObject(t, evaluated, expected)
+			default:
+				testNullObject(t, evaluated)
+			}
+		})
+	}
+}
+
+func TestOptionalChaining(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let c = {"db": {"port": 5432}}; c?.db?.port`, 5432},
+		{`let c = {"db": {"port": 5432}}; c?.cache?.port`, nil},
+		{`let c = {"db": {"port": 5432}}; c?["db"]?["port"]`, 5432},
+		{`let c = {}; c?["db"]?["port"] ?? 80`, 80},
+		{`null?[0]`, nil},
+		{`[1, 2]?[1]`, 2},
+		{`let f = fn(x) { x * 2 }; f?.(2)`, 4},
+		{`let h = {}; h?.f?.(2)`, nil},
+		{`null?.(-true)`, nil},
+		{`5?.x`, "member access not supported: integer.x"},
+		// a short-circuited link skips the rest of its chain
+		{`null?.a.b`, nil},
+		{`null?.a[0]`, nil},
+		{`null?.f(1).x`, nil},
+		{`null?[0][1:]`, nil},
+		{`let c = {}; c.db?.port.number`, nil},
+		{`let c = {"db": null}; c?.db.port`, "member access not supported: null.port"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+
+			switch expected := tt.expected.(type) {
+			case int:
+				testIntegerObject(t, evaluated, int64(expected))
+			case string:
+				errObj, ok := evaluated.(*object.Error)
+				if !ok {
+					t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				}
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+			default:
+				testNullObject(t, evaluated)
+			}
+		})
+	}
+}
+
 func testIntegerObject(t *testing.T, obj object.Object, expected int64) bool {
 	result, ok := obj.(*object.Integer)
 
@@ -458,3 +644,213 @@ func testNullObject(t *testing.T, obj object.Object) bool {
 	}
 	return true
 }
+
+func testStringObject(t *testing.T, obj object.Object, expected string) bool {
+	result, ok := obj.(*object.String)
+
+	if !ok {
+		t.Errorf("object is not String. got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%q, want=%q", result.Value, expected)
+		return false
+	}
+
+	return true
+}
+
+func testErrorObject(t *testing.T, obj object.Object, expected string) bool {
+	result, ok := obj.(*object.Error)
+
+	if !ok {
+		t.Errorf("object is not Error. got=%T (%+v)", obj, obj)
+		return false
+	}
+
+	if result.Message != expected {
+		t.Errorf("wrong error message. got=%q, want=%q", result.Message, expected)
+		return false
+	}
+
+	return true
+}
+
+// The expected results of tables checked with testObject. Arrays are expected as
+// []interface{}; ints, bools, strings and nil stand for themselves.
+type (
+	expectedError string
+	expectedTuple []interface{}
+	expectedSet   []interface{}
+	expectedHash  map[interface{}]interface{}
+
+	expectedStruct struct {
+		name   string
+		fields map[string]interface{}
+	}
+	expectedInstance struct {
+		class  string
+		fields map[string]interface{}
+	}
+	expectedEnumValue struct {
+		variant string
+		values  []interface{}
+	}
+
+	// inspected is expected of objects that are only compared by type and by how
+	// they're printed, such as functions and iterators.
+	inspected struct {
+		typ   object.ObjectType
+		value string
+	}
+)
+
+func testObject(t *testing.T, obj object.Object, expected interface{}) bool {
+	switch expected := expected.(type) {
+	case int:
+		return testIntegerObject(t, obj, int64(expected))
+	case bool:
+		return testBooleanObject(t, obj, expected)
+	case string:
+		return testStringObject(t, obj, expected)
+	case nil:
+		return testNullObject(t, obj)
+	case expectedError:
+		return testErrorObject(t, obj, string(expected))
+	case []interface{}:
+		array, ok := obj.(*object.Array)
+		if !ok {
+			t.Errorf("object is not Array. got=%T (%+v)", obj, obj)
+			return false
+		}
+		return testElements(t, array.Elements, expected)
+	case expectedTuple:
+		tuple, ok := obj.(*object.Tuple)
+		if !ok {
+			t.Errorf("object is not Tuple. got=%T (%+v)", obj, obj)
+			return false
+		}
+		return testElements(t, tuple.Elements, expected)
+	case expectedSet:
+		set, ok := obj.(*object.Set)
+		if !ok {
+			t.Errorf("object is not Set. got=%T (%+v)", obj, obj)
+			return false
+		}
+		return testElements(t, set.OrderedElements(), expected)
+	case expectedHash:
+		hash, ok := obj.(*object.Hash)
+		if !ok {
+			t.Errorf("object is not Hash. got=%T (%+v)", obj, obj)
+			return false
+		}
+		return testHashPairs(t, hash, expected)
+	case expectedStruct:
+		result, ok := obj.(*object.Struct)
+		if !ok {
+			t.Errorf("object is not Struct. got=%T (%+v)", obj, obj)
+			return false
+		}
+		if result.Definition.Name != expected.name {
+			t.Errorf("struct has wrong type. got=%q, want=%q", result.Definition.Name, expected.name)
+			return false
+		}
+		return testFields(t, result.Fields, expected.fields)
+	case expectedInstance:
+		result, ok := obj.(*object.Instance)
+		if !ok {
+			t.Errorf("object is not Instance. got=%T (%+v)", obj, obj)
+			return false
+		}
+		if result.Class.Name != expected.class {
+			t.Errorf("instance has wrong class. got=%q, want=%q", result.Class.Name, expected.class)
+			return false
+		}
+		return testFields(t, result.Fields, expected.fields)
+	case expectedEnumValue:
+		result, ok := obj.(*object.EnumValue)
+		if !ok {
+			t.Errorf("object is not EnumValue. got=%T (%+v)", obj, obj)
+			return false
+		}
+		if variant := result.Variant.Enum.Name + "." + result.Variant.Name; variant != expected.variant {
+			t.Errorf("enum value has wrong variant. got=%q, want=%q", variant, expected.variant)
+			return false
+		}
+		return testElements(t, result.Values, expected.values)
+	case inspected:
+		if obj.Type() != expected.typ || obj.Inspect() != expected.value {
+			t.Errorf("wrong object. got=%s %q, want=%s %q", obj.Type(), obj.Inspect(), expected.typ, expected.value)
+			return false
+		}
+		return true
+	}
+
+	t.Fatalf("type of expected result not handled. got=%T", expected)
+	return false
+}
+
+func testElements(t *testing.T, elements []object.Object, expected []interface{}) bool {
+	if len(elements) != len(expected) {
+		t.Errorf("wrong number of elements. got=%d, want=%d", len(elements), len(expected))
+		return false
+	}
+
+	for i, element := range elements {
+		if !testObject(t, element, expected[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func testHashPairs(t *testing.T, hash *object.Hash, expected expectedHash) bool {
+	if len(hash.Pairs) != len(expected) {
+		t.Errorf("hash has wrong number of pairs. got=%d, want=%d", len(hash.Pairs), len(expected))
+		return false
+	}
+
+	for key, value := range expected {
+		var hashKey object.HashKey
+		switch key := key.(type) {
+		case int:
+			hashKey = (&object.Integer{Value: int64(key)}).HashKey()
+		case bool:
+			hashKey = boolToBooleanObject(key).HashKey()
+		case string:
+			hashKey = (&object.String{Value: key}).HashKey()
+		default:
+			t.Fatalf("type of expected key not handled. got=%T", key)
+		}
+
+		pair, ok := hash.Pairs[hashKey]
+		if !ok {
+			t.Errorf("no pair for key %v in hash", key)
+			return false
+		}
+		if !testObject(t, pair.Value, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func testFields(t *testing.T, fields map[string]object.Object, expected map[string]interface{}) bool {
+	if len(fields) != len(expected) {
+		t.Errorf("wrong number of fields. got=%d, want=%d", len(fields), len(expected))
+		return false
+	}
+
+	for name, value := range expected {
+		field, ok := fields[name]
+		if !ok {
+			t.Errorf("no field %s", name)
+			return false
+		}
+		if !testObject(t, field, value) {
+			return false
+		}
+	}
+	return true
+}
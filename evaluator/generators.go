@@ -0,0 +1,101 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+	"runtime"
+)
+
+// errGeneratorStopped unwinds the body of a generator that was stopped while it
+// was waiting at a yield. It can't be caught by try expressions (although their
+// finally blocks are still run).
+var errGeneratorStopped = &object.Error{Message: "generator stopped", Kind: RUNTIME_ERROR}
+
+// newGenerator calls the generator function fn, returning an iterator over the
+// values yielded by its body. The body runs on its own goroutine, which starts
+// when the first value is requested and hands control back and forth with the
+// consumer at each yield, so only one of them is ever running.
+func newGenerator(fn *object.Function, args []object.Object) object.Object {
+	env, err := extendFunctionEnv(fn, args)
+	if err != nil {
+		return err
+	}
+
+	values := make(chan object.Object)
+	resume := make(chan struct{})
+	stopped := make(chan struct{})
+
+	env.SetYield(func(value object.Object) bool {
+		select {
+		case values <- value:
+		case <-stopped:
+			return false
+		}
+
+		select {
+		case <-resume:
+			return true
+		case <-stopped:
+			return false
+		}
+	})
+
+	run := func() {
+		defer close(values)
+
+		result := unwrapReturnValue(Eval(fn.Body, env))
+		if err, ok := result.(*object.Error); ok && err != errGeneratorStopped {
+			err.Stack = append(err.Stack, functionName(fn))
+			select {
+			case values <- err:
+			case <-stopped:
+			}
+		}
+	}
+
+	started, finished := false, false
+	next := func() (object.Object, bool) {
+		if !started {
+			started = true
+			go run()
+		} else {
+			resume <- struct{}{}
+		}
+
+		value, ok := <-values
+		finished = !ok
+		return value, ok
+	}
+	stop := func() {
+		if started && !finished {
+			close(stopped)
+		}
+	}
+
+	iterator := object.NewIterator(functionName(fn), next, stop)
+
+	// A generator that's dropped before it finishes would otherwise leave its
+	// goroutine waiting to be resumed forever.
+	runtime.SetFinalizer(iterator, (*object.Iterator).Stop)
+
+	return iterator
+}
+
+func evalYieldExpression(node *ast.YieldExpression, env *object.Environment) object.Object {
+	var value object.Object = NULL
+	if node.Value != nil {
+		if value = Eval(node.Value, env); isError(value) {
+			return value
+		}
+	}
+
+	yield, ok := env.Yield()
+	if !ok {
+		return newError("yield outside of a generator")
+	}
+	if !yield(value) {
+		return errGeneratorStopped
+	}
+
+	return NULL
+}
@@ -0,0 +1,69 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestGenerators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let gen = fn*() { yield 1; yield 2 }; gen", inspected{object.FUNCTION_OBJ, "fn* () {\nyield 1yield 2}"}},
+		{"let gen = fn*() { yield 1; yield 2 }; gen()", inspected{object.ITERATOR_OBJ, "iterator gen"}},
+		{"let gen = fn*() { yield 1; yield 2 }; collect(gen())", []interface{}{1, 2}},
+		{"let gen = fn*() { yield; }; collect(gen())", []interface{}{nil}},
+		{"let gen = fn*(n) { for (x in range(n)) { yield x * x } }; collect(gen(4))", []interface{}{0, 1, 4, 9}},
+		{"let gen = fn*() { yield 1; return 5; yield 2 }; collect(gen())", []interface{}{1}},
+		{"let gen = fn*() { yield 1 }; let g = gen(); [next(g), next(g), next(g)]", []interface{}{1, nil, nil}},
+		{"let gen = fn*() { yield 1 }; let g = gen(); [g.next(), g.collect()]", []interface{}{1, []interface{}{}}},
+		{
+			"let naturals = fn*() { let loop = fn*(n) { yield n; for (x in loop(n + 1)) { yield x } }; for (x in loop(0)) { yield x } }; collect(take(naturals(), 5))",
+			[]interface{}{0, 1, 2, 3, 4},
+		},
+		{
+			"let fib = fn*() { let step = fn*(a, b) { yield a; for (x in step(b, a + b)) { yield x } }; for (x in step(0, 1)) { yield x } }; fib().take(8).collect()",
+			[]interface{}{0, 1, 1, 2, 3, 5, 8, 13},
+		},
+		{"let gen = fn*() { yield 1; throw \"oops\" }; collect(gen())", expectedError("oops")},
+		{"let gen = fn*() { yield 1; throw \"oops\" }; let g = gen(); [next(g), try { next(g) } catch (e) { e.message }, next(g)]", []interface{}{1, "oops", nil}},
+		{"let gen = fn*() { for (x in [1, 2, 3]) { yield x } }; let f = fn() { for (x in gen()) { if (x == 2) { return x } } }; f()", 2},
+		{"let gen = fn*(a, b) { yield a }; gen(1)", expectedError("wrong number of arguments. got=1, want=2")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+// Stopping a generator that's waiting at a yield must end its goroutine, even if
+// the yield is inside a try expression.
+func TestStoppedGeneratorsExit(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	evaluated := testEval(`
+		let gen = fn*() {
+			for (x in range(1000000000)) {
+				try { yield x } catch (e) { }
+			}
+		};
+		collect(take(gen(), 3))
+	`)
+	if evaluated.Inspect() != "[0,1,2]" {
+		t.Fatalf("wrong result. got=%q", evaluated.Inspect())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("generator goroutine still running. goroutines before=%d, after=%d", before, after)
+	}
+}
@@ -0,0 +1,115 @@
+package evaluator
+
+import "monkey-interpreter/object"
+
+// hashBuiltins are available globally and exported by std/hash. Keys, values and
+// entries are returned in the order the keys were inserted.
+var hashBuiltins = map[string]*object.Builtin{
+	"keys": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("keys", args, object.HASH_OBJ); err != nil {
+				return err
+			}
+
+			keys := make([]object.Object, 0)
+			for _, pair := range args[0].(*object.Hash).OrderedPairs() {
+				keys = append(keys, pair.Key)
+			}
+			return &object.Array{Elements: keys}
+		},
+	},
+	"values": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("values", args, object.HASH_OBJ); err != nil {
+				return err
+			}
+
+			values := make([]object.Object, 0)
+			for _, pair := range args[0].(*object.Hash).OrderedPairs() {
+				values = append(values, pair.Value)
+			}
+			return &object.Array{Elements: values}
+		},
+	},
+	// entries returns the pairs of a hash as [key, value] arrays.
+	"entries": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("entries", args, object.HASH_OBJ); err != nil {
+				return err
+			}
+
+			entries := make([]object.Object, 0)
+			for _, pair := range args[0].(*object.Hash).OrderedPairs() {
+				entries = append(entries, &object.Array{Elements: []object.Object{pair.Key, pair.Value}})
+			}
+			return &object.Array{Elements: entries}
+		},
+	},
+	"has": {
+		Fn: func(args ...object.Object) object.Object {
+			hash, key, err := hashAndKeyArgs("has", args)
+			if err != nil {
+				return err
+			}
+
+			_, ok := hash.Pairs[key]
+			return boolToBooleanObject(ok)
+		},
+	},
+	// delete removes a key from a hash in place and returns the hash.
+	"delete": {
+		Fn: func(args ...object.Object) object.Object {
+			hash, key, err := hashAndKeyArgs("delete", args)
+			if err != nil {
+				return err
+			}
+
+			hash.Delete(key)
+			return hash
+		},
+	},
+	// merge returns a new hash holding the pairs of each of its arguments, with
+	// the values of later hashes replacing those of earlier ones.
+	"merge": {
+		Fn: func(args ...object.Object) object.Object {
+			merged := object.NewHash()
+
+			for _, arg := range args {
+				hash, ok := arg.(*object.Hash)
+				if !ok {
+					return newError("argument to `merge` not supported, got %s", arg.Type())
+				}
+
+				for _, pair := range hash.OrderedPairs() {
+					merged.Set(pair.Key.(object.Hashable).HashKey(), pair)
+				}
+			}
+
+			return merged
+		},
+	},
+}
+
+func init() {
+	for name, builtin := range hashBuiltins {
+		builtins[name] = builtin
+	}
+}
+
+func hashAndKeyArgs(name string, args []object.Object) (*object.Hash, object.HashKey, *object.Error) {
+	if len(args) != 2 {
+		return nil, object.HashKey{}, newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	hash, ok := args[0].(*object.Hash)
+	if !ok {
+		return nil, object.HashKey{}, newError("argument to `%s` not supported, got %s", name, args[0].Type())
+	}
+
+	key, ok := object.HashKeyOf(args[1])
+	if !ok {
+		return nil, object.HashKey{}, newError("index is not a valid hash key (type: %s)", args[1].Type())
+	}
+
+	return hash, key, nil
+}
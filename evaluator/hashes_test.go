@@ -0,0 +1,49 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestHashBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`keys(merge({"b": 1}, {"a": 2}, {3: 3}))`, []interface{}{"b", "a", 3}},
+		{`values(merge({"b": 1}, {"a": 2}, {3: 3}))`, []interface{}{1, 2, 3}},
+		{`entries(merge({"b": 1}, {true: 2}))`, []interface{}{[]interface{}{"b", 1}, []interface{}{true, 2}}},
+		{`keys({})`, []interface{}{}},
+		{`[has({"a": 1}, "a"), has({"a": 1}, "b"), has({1: 1}, 1)]`, []interface{}{true, false, true}},
+		{`let h = merge({"a": 1}, {"b": 2}, {"c": 3}); delete(h, "b"); h`, expectedHash{"a": 1, "c": 3}},
+		{`delete({"a": 1}, "z")`, expectedHash{"a": 1}},
+		{`merge({"a": 1}, {"b": 2}, {"b": 3}, {"c": 4})`, expectedHash{"a": 1, "b": 3, "c": 4}},
+		{`let a = {"a": 1}; merge(a, {"a": 2}); a`, expectedHash{"a": 1}},
+		{`merge()`, expectedHash{}},
+		{`len({"a": 1, "b": 2})`, 2},
+		{`keys([1])`, expectedError("argument to `keys` not supported, got ARRAY")},
+		{`has({}, [{}])`, expectedError("index is not a valid hash key (type: ARRAY)")},
+		{`delete({})`, expectedError("wrong number of arguments. got=1, want=2")},
+		{`merge({}, 1)`, expectedError("argument to `merge` not supported, got integer")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestHashInsertionOrder(t *testing.T) {
+	hash := object.NewHash()
+	for _, key := range []string{"c", "a", "b", "a"} {
+		k := &object.String{Value: key}
+		hash.Set(k.HashKey(), object.HashPair{Key: k, Value: k})
+	}
+	hash.Delete((&object.String{Value: "c"}).HashKey())
+
+	if hash.Inspect() != "{a:a, b:b}" {
+		t.Errorf("wrong order. got=%q", hash.Inspect())
+	}
+}
@@ -0,0 +1,201 @@
+package evaluator
+
+import "monkey-interpreter/object"
+
+func init() {
+	iteratorBuiltins := map[string]*object.Builtin{
+		"iter":    {Fn: builtinIter},
+		"next":    {Fn: builtinNext},
+		"collect": {Fn: builtinCollect},
+		"take":    {Fn: builtinTake},
+		"drop":    {Fn: builtinDrop},
+	}
+
+	for name, builtin := range iteratorBuiltins {
+		builtins[name] = builtin
+	}
+}
+
+// iterate returns an iterator over the elements of obj. Iterators are returned as
+// they are, hashes are iterated over by key and channels produce the values sent
+// on them until they're closed.
+func iterate(obj object.Object) (*object.Iterator, bool) {
+	var elements []object.Object
+
+	switch obj := obj.(type) {
+	case *object.Iterator:
+		return obj, true
+	case *object.Channel:
+		return object.NewIterator("channel", obj.Receive, nil), true
+	case *object.Array:
+		elements = obj.Elements
+	case *object.Tuple:
+		elements = obj.Elements
+	case *object.Set:
+		elements = obj.OrderedElements()
+	case *object.String:
+		for _, r := range obj.Value {
+			elements = append(elements, &object.String{Value: string(r)})
+		}
+	case *object.Hash:
+		for _, pair := range obj.OrderedPairs() {
+			elements = append(elements, pair.Key)
+		}
+	default:
+		return nil, false
+	}
+
+	i := 0
+	return object.NewIterator(string(obj.Type()), func() (object.Object, bool) {
+		if i >= len(elements) {
+			return nil, false
+		}
+		i++
+		return elements[i-1], true
+	}, nil), true
+}
+
+func builtinIter(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+
+	iterator, ok := iterate(args[0])
+	if !ok {
+		return newError("argument to `iter` not supported, got %s", args[0].Type())
+	}
+	return iterator
+}
+
+// builtinNext returns the next value of an iterator, or null once it's exhausted.
+func builtinNext(args ...object.Object) object.Object {
+	if err := checkArgs("next", args, object.ITERATOR_OBJ); err != nil {
+		return err
+	}
+
+	value, ok := args[0].(*object.Iterator).Next()
+	if !ok {
+		return NULL
+	}
+	return value
+}
+
+// builtinCollect returns an array of the remaining values of an iterator, or of
+// the values sent on a channel until it's closed.
+func builtinCollect(args ...object.Object) object.Object {
+	if len(args) != 1 {
+		return newError("wrong number of arguments. got=%d, want=1", len(args))
+	}
+	if args[0].Type() != object.ITERATOR_OBJ && args[0].Type() != object.CHANNEL_OBJ {
+		return newError("argument to `collect` not supported, got %s", args[0].Type())
+	}
+
+	iterator, _ := iterate(args[0])
+	elements := make([]object.Object, 0)
+	for value, ok := iterator.Next(); ok; value, ok = iterator.Next() {
+		if isError(value) {
+			return value
+		}
+		elements = append(elements, value)
+	}
+
+	return &object.Array{Elements: elements}
+}
+
+// builtinTake returns an iterator over the first n elements of an iterable,
+// stopping the underlying iterator once they've been produced.
+func builtinTake(args ...object.Object) object.Object {
+	source, n, err := iterableAndCountArgs("take", args)
+	if err != nil {
+		return err
+	}
+
+	taken := int64(0)
+	return object.NewIterator("take", func() (object.Object, bool) {
+		if taken >= n {
+			source.Stop()
+			return nil, false
+		}
+		taken++
+		return source.Next()
+	}, source.Stop)
+}
+
+// builtinDrop returns an iterator over the elements of an iterable after the
+// first n.
+func builtinDrop(args ...object.Object) object.Object {
+	source, n, err := iterableAndCountArgs("drop", args)
+	if err != nil {
+		return err
+	}
+
+	return object.NewIterator("drop", func() (object.Object, bool) {
+		for ; n > 0; n-- {
+			if value, ok := source.Next(); !ok || isError(value) {
+				return value, ok
+			}
+		}
+		return source.Next()
+	}, source.Stop)
+}
+
+// mapIterator lazily applies fn to each value of source.
+func mapIterator(source *object.Iterator, fn object.Object) *object.Iterator {
+	return object.NewIterator("map", func() (object.Object, bool) {
+		value, ok := source.Next()
+		if !ok || isError(value) {
+			return value, ok
+		}
+		return applyFunction(fn, []object.Object{value}), true
+	}, source.Stop)
+}
+
+// filterIterator lazily produces the values of source for which fn returns a
+// truthy value.
+func filterIterator(source *object.Iterator, fn object.Object) *object.Iterator {
+	return object.NewIterator("filter", func() (object.Object, bool) {
+		for {
+			value, ok := source.Next()
+			if !ok || isError(value) {
+				return value, ok
+			}
+
+			result := applyFunction(fn, []object.Object{value})
+			if isError(result) {
+				return result, true
+			}
+			if isTruthy(result) {
+				return value, true
+			}
+		}
+	}, source.Stop)
+}
+
+func iterableAndCountArgs(name string, args []object.Object) (*object.Iterator, int64, *object.Error) {
+	if len(args) != 2 {
+		return nil, 0, newError("wrong number of arguments. got=%d, want=2", len(args))
+	}
+
+	iterator, ok := iterate(args[0])
+	if !ok {
+		return nil, 0, newError("argument to `%s` not supported, got %s", name, args[0].Type())
+	}
+
+	count, ok := args[1].(*object.Integer)
+	if !ok {
+		return nil, 0, newError("argument to `%s` not supported, got %s", name, args[1].Type())
+	}
+
+	return iterator, count.Value, nil
+}
+
+// iteratorAndFunctionArgs reports whether args are an iterator and a function, as
+// accepted by the lazy versions of map and filter.
+func iteratorAndFunctionArgs(args []object.Object) (*object.Iterator, object.Object, bool) {
+	if len(args) != 2 || !isCallable(args[1]) {
+		return nil, nil, false
+	}
+
+	iterator, ok := args[0].(*object.Iterator)
+	return iterator, args[1], ok
+}
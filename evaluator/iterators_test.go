@@ -0,0 +1,39 @@
+package evaluator
+
+import "testing"
+
+func TestIterators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"collect(iter([1, 2]))", []interface{}{1, 2}},
+		{`collect(iter("héllo"))`, []interface{}{"h", "é", "l", "l", "o"}},
+		{`collect(iter({"a": 1, "b": 2}))`, []interface{}{"a", "b"}},
+		{"collect(iter(#{3, 1}))", []interface{}{3, 1}},
+		{"let it = iter((1, 2)); iter(it) == it", true},
+		{"let it = range(2); [next(it), next(it), next(it)]", []interface{}{0, 1, nil}},
+		{"collect(take(range(1000000000000), 3))", []interface{}{0, 1, 2}},
+		{"collect(drop(range(5), 3))", []interface{}{3, 4}},
+		{"collect(drop(range(2), 3))", []interface{}{}},
+		{"collect(take([1, 2, 3], 2))", []interface{}{1, 2}},
+		{"collect(map(range(4), fn(x) { x * 10 }))", []interface{}{0, 10, 20, 30}},
+		{"collect(filter(range(10), fn(x) { x / 3 * 3 == x }))", []interface{}{0, 3, 6, 9}},
+		{"range(10).filter(fn(x) { x > 6 }).map(fn(x) { x * 2 }).collect()", []interface{}{14, 16, 18}},
+		{"reduce(range(101), 0, fn(sum, x) { sum + x })", 5050},
+		{"let f = fn() { for (i, x in range(3, 6)) { if (i == 1) { return x } } }; f()", 4},
+		{"collect(map(range(3), fn(x) { if (x == 1) { throw \"bad\" } else { x } }))", expectedError("bad")},
+		{"let it = map(range(3), fn(x) { if (x == 0) { throw \"bad\" } else { x } }); try { next(it) } catch (e) { 0 }; collect(it)", []interface{}{}},
+		{"iter(1)", expectedError("argument to `iter` not supported, got integer")},
+		{"next([1])", expectedError("argument to `next` not supported, got ARRAY")},
+		{"take(range(3), \"2\")", expectedError("argument to `take` not supported, got STRING")},
+		{"len(range(3))", expectedError("argument to `len` not supported, got ITERATOR")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
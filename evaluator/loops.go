@@ -0,0 +1,104 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+func evalForExpression(fe *ast.ForExpression, env *object.Environment) object.Object {
+	iterable := Eval(fe.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	// Each iteration binds the element (or key, for hashes) to a single variable.
+	// With two variables, arrays, tuples, sets, strings, iterators and channels
+	// bind (index, element) and hashes bind (key, value). Pairs are stored with
+	// the single variable's value first.
+	var pairs [][2]object.Object
+
+	switch iterable := iterable.(type) {
+	case *object.Iterator, *object.Channel:
+		iterator, _ := iterate(iterable)
+		return evalForIterator(fe, iterator, env)
+	case *object.Array:
+		for i, element := range iterable.Elements {
+			pairs = append(pairs, [2]object.Object{element, intToIntegerObject(int64(i))})
+		}
+	case *object.Tuple:
+		for i, element := range iterable.Elements {
+			pairs = append(pairs, [2]object.Object{element, intToIntegerObject(int64(i))})
+		}
+	case *object.Set:
+		for i, element := range iterable.OrderedElements() {
+			pairs = append(pairs, [2]object.Object{element, intToIntegerObject(int64(i))})
+		}
+	case *object.String:
+		for i, r := range []rune(iterable.Value) {
+			pairs = append(pairs, [2]object.Object{&object.String{Value: string(r)}, intToIntegerObject(int64(i))})
+		}
+	case *object.Hash:
+		for _, pair := range iterable.OrderedPairs() {
+			pairs = append(pairs, [2]object.Object{pair.Key, pair.Value})
+		}
+	default:
+		return newError("cannot iterate over %s", iterable.Type())
+	}
+
+	for _, pair := range pairs {
+		values := pair[:1]
+		if len(fe.Variables) == 2 {
+			values = pair[:]
+			if iterable.Type() != object.HASH_OBJ {
+				values = []object.Object{pair[1], pair[0]}
+			}
+		}
+
+		if result := evalForIteration(fe, values, env); result != nil {
+			return result
+		}
+	}
+
+	return NULL
+}
+
+// evalForIterator runs a for loop over the values of an iterator as they're
+// produced, stopping the iterator if the loop exits early.
+func evalForIterator(fe *ast.ForExpression, iterator *object.Iterator, env *object.Environment) object.Object {
+	for i := int64(0); ; i++ {
+		value, ok := iterator.Next()
+		if !ok {
+			return NULL
+		}
+		if isError(value) {
+			return value
+		}
+
+		values := []object.Object{value}
+		if len(fe.Variables) == 2 {
+			values = []object.Object{intToIntegerObject(i), value}
+		}
+
+		if result := evalForIteration(fe, values, env); result != nil {
+			iterator.Stop()
+			return result
+		}
+	}
+}
+
+// evalForIteration binds values to the loop's variables and evaluates its body,
+// returning the result if it's a return value or an error that ends the loop.
+func evalForIteration(fe *ast.ForExpression, values []object.Object, env *object.Environment) object.Object {
+	loopEnv := object.NewFrame(env, fe.Locals)
+	for i, variable := range fe.Variables {
+		if err := bindPattern(variable, values[i], loopEnv); err != nil {
+			return err
+		}
+	}
+
+	result := Eval(fe.Body, loopEnv)
+	if result != nil && (result.Type() == object.RETURN_VALUE_OBJ || result.Type() == object.ERROR_OBJ) {
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package evaluator
+
+import "testing"
+
+func TestForExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"for (x in [1, 2, 3]) { x }", nil},
+		{"let f = fn() { for (x in [1, 2, 3]) { if (x > 1) { return x } } }; f()", 2},
+		{"let f = fn() { for (i, x in [5, 6, 7]) { if (x == 6) { return i } } }; f()", 1},
+		{`let f = fn() { for (c in "héllo") { if (c != "h") { return c } } }; f()`, "é"},
+		{`let f = fn() { for (k in merge({"a": 1}, {"b": 2})) { return k } }; f()`, "a"},
+		{`let f = fn() { for (k, v in {"a": 1, "b": 2}) { if (v == 2) { return k + "!" } } }; f()`, "b!"},
+		{"let f = fn() { for ([a, b] in [[1, 2], [3, 4]]) { return a + b } }; f()", 3},
+		{`let h = merge({"a": 1}, {"b": 2}); for (k in h) { delete(h, k) }; h`, expectedHash{}},
+		{"for (x in [1, 2]) { x + true }", expectedError("type mismatch: integer + boolean")},
+		{"for (x in 5) { x }", expectedError("cannot iterate over integer")},
+		{"for ([a] in [1]) { a }", expectedError("cannot destructure integer as array")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
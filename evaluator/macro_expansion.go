@@ -0,0 +1,126 @@
+package evaluator
+
+import (
+	"fmt"
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+// DefineMacros removes all top-level `let <name> = macro(...) { ... }` statements
+// from program and binds the macros they define in env.
+func DefineMacros(program *ast.AST, env *object.Environment) {
+	statements := make([]ast.Statement, 0, len(program.Statements))
+
+	for _, statement := range program.Statements {
+		name, macro, ok := macroDefinition(statement)
+		if !ok {
+			statements = append(statements, statement)
+			continue
+		}
+
+		env.Set(name, &object.Macro{
+			Parameters: macro.Parameters,
+			Body:       macro.Body,
+			Env:        env,
+		})
+	}
+
+	program.Statements = statements
+}
+
+func macroDefinition(statement ast.Statement) (string, *ast.MacroLiteral, bool) {
+	letStatement, ok := statement.(*ast.LetStatement)
+	if !ok {
+		return "", nil, false
+	}
+
+	name, ok := letStatement.Name.(*ast.Identifier)
+	if !ok {
+		return "", nil, false
+	}
+
+	macro, ok := letStatement.Value.(*ast.MacroLiteral)
+	if !ok {
+		return "", nil, false
+	}
+
+	return name.Value, macro, true
+}
+
+// ExpandMacros replaces every call to a macro defined in env with the quoted AST
+// returned by evaluating the macro's body with its (unevaluated) arguments.
+func ExpandMacros(program ast.Node, env *object.Environment) (ast.Node, error) {
+	var err error
+
+	expanded := ast.Modify(program, func(node ast.Node) ast.Node {
+		if err != nil {
+			return node
+		}
+
+		call, ok := node.(*ast.CallExpression)
+		if !ok {
+			return node
+		}
+
+		macro, ok := macroForCall(call, env)
+		if !ok {
+			return node
+		}
+
+		if len(call.Arguments) != len(macro.Parameters) {
+			err = fmt.Errorf("wrong number of arguments to macro %s. got=%d, want=%d",
+				call.Function, len(call.Arguments), len(macro.Parameters))
+			return node
+		}
+
+		evalEnv := object.NewEnclosingEnvironment(macro.Env)
+		for i, param := range macro.Parameters {
+			evalEnv.Set(param.Value, &object.Quote{Node: call.Arguments[i]})
+		}
+
+		evaluated := Eval(macro.Body, evalEnv)
+		if errObj, ok := evaluated.(*object.Error); ok {
+			err = fmt.Errorf("error expanding macro %s: %s", call.Function, errObj.Message)
+			return node
+		}
+
+		quoted, ok := unwrapReturnValue(evaluated).(*object.Quote)
+		if !ok {
+			err = fmt.Errorf("macro %s must return a quoted AST node", call.Function)
+			return node
+		}
+
+		// Quoted nodes can be spliced in more than once, and the resolver
+		// stores the addresses of identifiers in the tree, so each expansion
+		// gets its own copy.
+		return copyNode(quoted.Node)
+	})
+
+	return expanded, err
+}
+
+// copyNode copies node and each expression and statement within it.
+func copyNode(node ast.Node) ast.Node {
+	return ast.Modify(node, func(node ast.Node) ast.Node {
+		if identifier, ok := node.(*ast.Identifier); ok {
+			copied := *identifier
+			return &copied
+		}
+		return node
+	})
+}
+
+func macroForCall(call *ast.CallExpression, env *object.Environment) (*object.Macro, bool) {
+	identifier, ok := call.Function.(*ast.Identifier)
+	if !ok {
+		return nil, false
+	}
+
+	obj, ok := env.Get(identifier.Value)
+	if !ok {
+		return nil, false
+	}
+
+	macro, ok := obj.(*object.Macro)
+	return macro, ok
+}
@@ -0,0 +1,205 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/lexer"
+	"monkey-interpreter/object"
+	"monkey-interpreter/parser"
+	"testing"
+)
+
+func TestDefineMacros(t *testing.T) {
+	input := `
+	let number = 1;
+	let function = fn(x, y) { x + y };
+	let mymacro = macro(x, y) { x + y; };
+	`
+
+	env := object.NewEnvironment()
+	program := testParseProgram(t, input)
+
+	DefineMacros(program, env)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("Wrong number of statements. got=%d", len(program.Statements))
+	}
+
+	if _, ok := env.Get("number"); ok {
+		t.Fatalf("number should not be defined")
+	}
+	if _, ok := env.Get("function"); ok {
+		t.Fatalf("function should not be defined")
+	}
+
+	obj, ok := env.Get("mymacro")
+	if !ok {
+		t.Fatalf("macro not in environment.")
+	}
+
+	macro, ok := obj.(*object.Macro)
+	if !ok {
+		t.Fatalf("object is not Macro. got=%T (%+v)", obj, obj)
+	}
+
+	if len(macro.Parameters) != 2 {
+		t.Fatalf("Wrong number of macro parameters. got=%d", len(macro.Parameters))
+	}
+
+	if macro.Parameters[0].String() != "x" || macro.Parameters[1].String() != "y" {
+		t.Fatalf("parameters wrong. got=%v", macro.Parameters)
+	}
+
+	expectedBody := "(x + y)"
+	if macro.Body.String() != expectedBody {
+		t.Fatalf("body is not %q. got=%q", expectedBody, macro.Body.String())
+	}
+}
+
+func TestExpandMacros(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`let infixExpression = macro() { quote(1 + 2); };
+			infixExpression();`,
+			`(1 + 2)`,
+		},
+		{
+			`let reverse = macro(a, b) { quote(unquote(b) - unquote(a)); };
+			reverse(2 + 2, 10 - 5);`,
+			`(10 - 5) - (2 + 2)`,
+		},
+		{
+			`let unless = macro(condition, consequence, alternative) {
+				quote(if (!(unquote(condition))) {
+					unquote(consequence);
+				} else {
+					unquote(alternative);
+				});
+			};
+
+			unless(10 > 5, puts("not greater"), puts("greater"));`,
+			`if (!(10 > 5)) { puts("not greater") } else { puts("greater") }`,
+		},
+		{
+			`let twice = macro(x) { quote(unquote(x) + unquote(x)) };
+			twice(1); twice(2);`,
+			`(1 + 1); (2 + 2)`,
+		},
+	}
+
+	for _, tt := range tests {
+		expected := testParseProgram(t, tt.expected)
+		program := testParseProgram(t, tt.input)
+
+		env := object.NewEnvironment()
+		DefineMacros(program, env)
+
+		expanded, err := ExpandMacros(program, env)
+		if err != nil {
+			t.Fatalf("unexpected error expanding macros: %s", err)
+		}
+
+		if expanded.String() != expected.String() {
+			t.Errorf("not equal. want=%q, got=%q", expected.String(), expanded.String())
+		}
+	}
+}
+
+func TestExpandMacrosErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let m = macro(x) { quote(x) }; m(1, 2)`, "wrong number of arguments to macro m. got=2, want=1"},
+		{`let m = macro(x) { 1 }; m(1)`, "macro m must return a quoted AST node"},
+		{`let m = macro(x) { missing }; m(1)`, "error expanding macro m: identifier not found: missing"},
+	}
+
+	for _, tt := range tests {
+		program := testParseProgram(t, tt.input)
+
+		env := object.NewEnvironment()
+		DefineMacros(program, env)
+
+		_, err := ExpandMacros(program, env)
+		if err == nil {
+			t.Errorf("expected error expanding %q", tt.input)
+			continue
+		}
+
+		if err.Error() != tt.expected {
+			t.Errorf("wrong error. want=%q, got=%q", tt.expected, err.Error())
+		}
+	}
+}
+
+func TestMacroEvaluation(t *testing.T) {
+	input := `
+	let unless = macro(condition, consequence, alternative) {
+		quote(if (!(unquote(condition))) { unquote(consequence) } else { unquote(alternative) });
+	};
+	unless(1 > 2, 10, missing);
+	`
+
+	program := testParseProgram(t, input)
+	macroEnv := object.NewEnvironment()
+	DefineMacros(program, macroEnv)
+
+	expanded, err := ExpandMacros(program, macroEnv)
+	if err != nil {
+		t.Fatalf("unexpected error expanding macros: %s", err)
+	}
+
+	testIntegerObject(t, Eval(expanded, object.NewEnvironment()), 10)
+}
+
+// Macros defined on one line of the REPL are expanded into the programs on later
+// lines, which are resolved separately.
+func TestMacroExpansionAcrossPrograms(t *testing.T) {
+	lines := []struct {
+		input    string
+		expected string
+	}{
+		{"let m = macro() { quote(v) };", ""},
+		{"let f = fn(v, b) { m() };", ""},
+		{"f(7, 8)", "7"},
+		{"let g = fn(a, v) { m() };", ""},
+		{"[f(7, 8), g(7, 8)]", "[7,8]"},
+		{"let n = macro(e) { quote(fn() { unquote(e) }() + unquote(e)) };", ""},
+		{"let h = fn(x) { n(g(0, x)) }; h(5)", "10"},
+	}
+
+	env := object.NewEnvironment()
+	macroEnv := object.NewEnvironment()
+	for _, line := range lines {
+		program := testParseProgram(t, line.input)
+		DefineMacros(program, macroEnv)
+
+		expanded, err := ExpandMacros(program, macroEnv)
+		if err != nil {
+			t.Fatalf("unexpected error expanding macros in %q: %s", line.input, err)
+		}
+		if errs := Resolve(expanded.(*ast.AST), env); len(errs) != 0 {
+			t.Fatalf("unexpected errors resolving %q: %v", line.input, errs)
+		}
+
+		evaluated := Eval(expanded, env)
+		if line.expected != "" && evaluated.Inspect() != line.expected {
+			t.Errorf("wrong result for %q. want=%q, got=%q", line.input, line.expected, evaluated.Inspect())
+		}
+	}
+}
+
+func testParseProgram(t *testing.T, input string) *ast.AST {
+	t.Helper()
+
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		t.Fatalf("unexpected parser errors: %v", p.Errors())
+	}
+	return program
+}
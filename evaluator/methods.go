@@ -0,0 +1,37 @@
+package evaluator
+
+import "monkey-interpreter/object"
+
+// methods lists the builtins that can be called as methods of values of each
+// type, with the value passed as the first argument (so `"a".upper()` is
+// `upper("a")`). Methods are looked up by name in builtins when they're used,
+// so they can refer to builtins registered by init functions.
+var methods = map[object.ObjectType][]string{
+	object.STRING_OBJ: {
+		"len", "split", "trim", "upper", "lower", "contains", "replace", "startsWith",
+		"endsWith", "padLeft", "repeat", "chars",
+	},
+	object.ARRAY_OBJ: {
+		"len", "first", "last", "tail", "push", "join", "map", "filter", "reduce",
+		"sort", "find", "any", "all", "zip", "reverse", "flatten", "uniq",
+	},
+	object.HASH_OBJ:    {"len", "keys", "values", "entries", "has", "delete", "merge"},
+	object.SET_OBJ:     {"len"},
+	object.TUPLE_OBJ:   {"len"},
+	object.CHANNEL_OBJ: {"send", "recv", "close"},
+	object.TASK_OBJ:    {"await"},
+	object.ITERATOR_OBJ: {
+		"next", "collect", "take", "drop", "map", "filter", "reduce",
+	},
+}
+
+// lookupMethod returns the method called name bound to obj, if obj's type has one.
+func lookupMethod(obj object.Object, name string) (object.Object, bool) {
+	for _, method := range methods[obj.Type()] {
+		if method == name {
+			return &object.BoundMethod{Receiver: obj, Name: name, Method: builtins[name]}, true
+		}
+	}
+
+	return nil, false
+}
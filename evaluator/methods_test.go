@@ -0,0 +1,61 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestMemberAccess(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let person = {"name": "Ada", "address": {"city": "London"}}; person.name`, "Ada"},
+		{`let person = {"name": "Ada", "address": {"city": "London"}}; person.address.city`, "London"},
+		{`{"name": "Ada"}.age`, nil},
+		{`let h = {"f": fn(x) { x * 2 }}; h.f(4)`, 8},
+		{`import "std/math" as m; m.abs(-2)`, 2},
+		{`5.x`, expectedError("member access not supported: integer.x")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestMethodCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"abc".upper()`, "ABC"},
+		{`"a,b".split(",")`, []interface{}{"a", "b"}},
+		{`"  x ".trim().len()`, 1},
+		{`"héllo".chars().reverse().join("")`, "olléh"},
+		{`[3, 1, 2].sort().map(fn(x) { x * 10 })`, []interface{}{10, 20, 30}},
+		{`[1, 2, 3].filter(fn(x) { x > 1 }).reduce(0, fn(a, b) { a + b })`, 5},
+		{`[1, 2].push(3).len()`, 3},
+		{`["a", "b"].join("-")`, "a-b"},
+		{`{"a": 1}.keys()`, []interface{}{"a"}},
+		{`{"keys": 1}.keys()`, []interface{}{"keys"}},
+		{`{"a": 1}.merge({"b": 2})`, expectedHash{"a": 1, "b": 2}},
+		{`#{1, 2}.len()`, 2},
+		{`(1, 2, 3).len()`, 3},
+		{`let upper = "abc".upper; upper()`, "ABC"},
+		{`"abc".upper`, inspected{object.BOUND_METHOD_OBJ, "bound method upper of STRING"}},
+		{`map(["a", "b"], fn(s) { s.upper() })`, []interface{}{"A", "B"}},
+		{`"abc".nope()`, expectedError("member access not supported: STRING.nope")},
+		{`"abc".repeat()`, expectedError("wrong number of arguments. got=1, want=2")},
+		{`let n = null; n?.upper?.()`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package evaluator
+
+import (
+	"fmt"
+	"monkey-interpreter/ast"
+	"monkey-interpreter/lexer"
+	"monkey-interpreter/object"
+	"monkey-interpreter/parser"
+	"monkey-interpreter/std"
+	"monkey-interpreter/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// IMPORT_ERROR is the kind of errors raised when a module can't be loaded.
+const IMPORT_ERROR = "ImportError"
+
+// MODULE_EXTENSION is appended to import paths that don't have an extension.
+const MODULE_EXTENSION = ".mk"
+
+// ModuleLoader locates, evaluates and caches the modules imported by scripts.
+// Each module is only evaluated once no matter how many times it's imported.
+type ModuleLoader struct {
+	// SearchPaths are the directories searched (in order) for modules that
+	// can't be found relative to the importing module.
+	SearchPaths []string
+
+	mu      sync.Mutex
+	modules map[string]*object.Module
+	// loading holds the modules currently being evaluated. Modules can be
+	// imported concurrently by spawned tasks, so importers of a module that's
+	// still loading wait for it rather than evaluating it again.
+	loading map[string]*pendingModule
+}
+
+// pendingModule is a module that's being loaded.
+type pendingModule struct {
+	// chain is the module itself preceded by the modules whose imports led to
+	// it being loaded, used to detect import cycles.
+	chain []string
+	// waiting counts the importers in the module that are waiting for each
+	// module being loaded by another task, so that cycles between modules
+	// loaded concurrently are detected rather than deadlocking.
+	waiting map[string]int
+	done    chan struct{}
+	result  object.Object
+}
+
+func NewModuleLoader() *ModuleLoader {
+	return &ModuleLoader{
+		modules: make(map[string]*object.Module),
+		loading: make(map[string]*pendingModule),
+	}
+}
+
+// Modules is the loader used to evaluate import statements.
+var Modules = NewModuleLoader()
+
+// RunFile evaluates the source file at path as the entry point of a program.
+func RunFile(path string) object.Object {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return newImportError("cannot find module %q: %s", path, err)
+	}
+
+	// The entry point is treated as loading for the duration of the program so
+	// that modules importing it are reported as cycles.
+	entry := Modules.begin(absPath, "")
+	result, _, _ := Modules.evalModule(absPath)
+	Modules.finish(absPath, entry, newImportError("cannot import %q while it's running", absPath))
+
+	return result
+}
+
+func evalImportStatement(is *ast.ImportStatement, env *object.Environment) object.Object {
+	path, err := Modules.resolve(is.Path.Value, env.ModulePath())
+	if err != nil {
+		return err
+	}
+
+	module := Modules.load(path, env.ModulePath())
+	if isError(module) {
+		return module
+	}
+
+	for _, name := range is.Names {
+		value, ok := module.(*object.Module).Exports[name.Value]
+		if !ok {
+			return newImportError("module %q has no export %s", is.Path.Value, name.Value)
+		}
+		if err := define(env, name.Value, value); err != nil {
+			return err
+		}
+	}
+
+	if is.Names == nil {
+		alias := moduleAlias(is)
+		if !token.IsValidIdentifier(alias) {
+			return newImportError("cannot import %q without an alias", is.Path.Value)
+		}
+		if err := define(env, alias, module); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// modules imported without an alias are bound to the name of their file.
+func moduleAlias(is *ast.ImportStatement) string {
+	if is.Alias != nil {
+		return is.Alias.Value
+	}
+
+	base := filepath.Base(is.Path.Value)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// resolve returns the absolute path of the module imported as path from the
+// module at importer. Paths are first resolved relative to the directory of the
+// importer (or the working directory) and then relative to each search path.
+// Standard library paths are returned unchanged.
+func (l *ModuleLoader) resolve(path, importer string) (string, *object.Error) {
+	if strings.HasPrefix(path, STD_PREFIX) {
+		if _, ok := stdModules[strings.TrimPrefix(path, STD_PREFIX)]; !ok {
+			return "", newImportError("cannot find module %q", path)
+		}
+		return path, nil
+	}
+
+	if filepath.Ext(path) == "" {
+		path += MODULE_EXTENSION
+	}
+
+	candidates := []string{path}
+	if !filepath.IsAbs(path) {
+		dir := "."
+		if importer != "" {
+			dir = filepath.Dir(importer)
+		}
+
+		candidates = []string{filepath.Join(dir, path)}
+		for _, searchPath := range l.SearchPaths {
+			candidates = append(candidates, filepath.Join(searchPath, path))
+		}
+	}
+
+	for _, candidate := range candidates {
+		info, err := os.Stat(candidate)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		absPath, err := filepath.Abs(candidate)
+		if err != nil {
+			continue
+		}
+		return absPath, nil
+	}
+
+	return "", newImportError("cannot find module %q", path)
+}
+
+// load returns the module at path (which must be absolute or a standard library
+// path) for the module at importer, evaluating it if it hasn't already been
+// loaded.
+func (l *ModuleLoader) load(path, importer string) object.Object {
+	l.mu.Lock()
+	if module, ok := l.modules[path]; ok {
+		l.mu.Unlock()
+		return module
+	}
+
+	pending, ok := l.loading[path]
+	if !ok {
+		pending = l.beginLocked(path, importer)
+		l.mu.Unlock()
+
+		result := l.evalExports(path)
+		l.finish(path, pending, result)
+		return result
+	}
+
+	if cycle := l.cycle(path, importer); cycle != nil {
+		l.mu.Unlock()
+		return newImportError("import cycle detected: %s", strings.Join(cycle, " -> "))
+	}
+
+	waiter := l.loading[importer]
+	if waiter != nil {
+		waiter.waiting[path]++
+	}
+	l.mu.Unlock()
+
+	<-pending.done
+
+	if waiter != nil {
+		l.mu.Lock()
+		waiter.waiting[path]--
+		l.mu.Unlock()
+	}
+	return pending.result
+}
+
+// begin records that the module at path is being loaded for the module at
+// importer.
+func (l *ModuleLoader) begin(path, importer string) *pendingModule {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.beginLocked(path, importer)
+}
+
+func (l *ModuleLoader) beginLocked(path, importer string) *pendingModule {
+	var chain []string
+	if parent, ok := l.loading[importer]; ok {
+		chain = append(chain, parent.chain...)
+	}
+
+	pending := &pendingModule{
+		chain:   append(chain, path),
+		waiting: make(map[string]int),
+		done:    make(chan struct{}),
+	}
+	l.loading[path] = pending
+	return pending
+}
+
+// finish records the result of loading the module at path, caching it if it was
+// loaded successfully, and wakes the importers waiting for it.
+func (l *ModuleLoader) finish(path string, pending *pendingModule, result object.Object) {
+	l.mu.Lock()
+	if module, ok := result.(*object.Module); ok {
+		l.modules[path] = module
+	}
+	delete(l.loading, path)
+	l.mu.Unlock()
+
+	pending.result = result
+	close(pending.done)
+}
+
+// cycle returns the chain of imports that would never finish if the module at
+// importer waited for the module at path to load, or nil if there isn't one.
+// That's the case if path is being loaded for importer, or if path is waiting
+// (through any number of other modules) for a module that is.
+func (l *ModuleLoader) cycle(path, importer string) []string {
+	var chain []string
+	if parent, ok := l.loading[importer]; ok {
+		chain = parent.chain
+	}
+
+	visited := make(map[string]bool)
+	var search func(path string, trail []string) []string
+	search = func(path string, trail []string) []string {
+		trail = append(trail, path)
+		for i, loading := range chain {
+			if loading == path {
+				return append(append([]string(nil), chain[i:]...), trail...)
+			}
+		}
+
+		if visited[path] {
+			return nil
+		}
+		visited[path] = true
+
+		if pending, ok := l.loading[path]; ok {
+			for waitingFor, count := range pending.waiting {
+				if count == 0 {
+					continue
+				}
+				if cycle := search(waitingFor, trail); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		return nil
+	}
+
+	cycle := search(path, nil)
+	for i, p := range cycle {
+		cycle[i] = filepath.Base(p)
+	}
+	return cycle
+}
+
+// evalExports evaluates the module at path and collects its exports.
+func (l *ModuleLoader) evalExports(path string) object.Object {
+	var result object.Object
+	var program *ast.AST
+	var env *object.Environment
+	if strings.HasPrefix(path, STD_PREFIX) {
+		result, program, env = l.evalStdModule(path)
+	} else {
+		result, program, env = l.evalModule(path)
+	}
+	if isError(result) {
+		return result
+	}
+
+	module := &object.Module{Path: path, Exports: make(map[string]object.Object)}
+	for name, builtin := range stdModules[strings.TrimPrefix(path, STD_PREFIX)] {
+		module.Exports[name] = builtin
+	}
+	for _, statement := range program.Statements {
+		export, ok := statement.(*ast.ExportStatement)
+		if !ok {
+			continue
+		}
+
+		for _, name := range export.Names() {
+			module.Exports[name], _ = env.Get(name)
+		}
+	}
+
+	return module
+}
+
+// evalModule parses, expands and evaluates the source file at path in a new
+// module environment.
+func (l *ModuleLoader) evalModule(path string) (object.Object, *ast.AST, *object.Environment) {
+	lex, err := lexer.NewForFile(path)
+	if err != nil {
+		return newImportError("cannot load module %q: %s", path, err), nil, nil
+	}
+
+	return l.evalSource(path, lex, object.NewModuleEnvironment(path))
+}
+
+// evalStdModule evaluates the embedded source of the standard library module at
+// path with the builtins backing it already in scope.
+func (l *ModuleLoader) evalStdModule(path string) (object.Object, *ast.AST, *object.Environment) {
+	name := strings.TrimPrefix(path, STD_PREFIX)
+
+	source, err := std.Sources.ReadFile(name + MODULE_EXTENSION)
+	if err != nil {
+		return newImportError("cannot load module %q: %s", path, err), nil, nil
+	}
+
+	env := object.NewModuleEnvironment(path)
+	for name, builtin := range stdModules[name] {
+		env.Set(name, builtin)
+	}
+
+	return l.evalSource(path, lexer.New(string(source)), env)
+}
+
+// evalSource parses, expands, resolves and evaluates the module at path in env.
+func (l *ModuleLoader) evalSource(path string, lex *lexer.Lexer, env *object.Environment) (object.Object, *ast.AST, *object.Environment) {
+	p := parser.New(lex)
+	program := p.ParseProgram()
+
+	if len(p.Errors()) != 0 {
+		return newImportError("cannot parse module %q: %s", path, joinErrors(p.Errors())), nil, nil
+	}
+
+	macroEnv := object.NewEnvironment()
+	DefineMacros(program, macroEnv)
+
+	expanded, err := ExpandMacros(program, macroEnv)
+	if err != nil {
+		return newImportError("cannot expand module %q: %s", path, err), nil, nil
+	}
+	program = expanded.(*ast.AST)
+
+	if errs := Resolve(program, env); len(errs) != 0 {
+		return newImportError("cannot resolve module %q: %s", path, joinErrors(errs)), nil, nil
+	}
+
+	result := Eval(program, env)
+
+	if errObj, ok := result.(*object.Error); ok {
+		errObj.Stack = append(errObj.Stack, fmt.Sprintf("<module %s>", filepath.Base(path)))
+	}
+
+	return result, program, env
+}
+
+func joinErrors(errs []error) string {
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func newImportError(format string, a ...interface{}) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...), Kind: IMPORT_ERROR}
+}
@@ -0,0 +1,207 @@
+package evaluator
+
+import (
+	"io/ioutil"
+	"monkey-interpreter/object"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeModules writes each of the given files (keyed by path relative to a new
+// temporary directory) and returns the directory.
+func writeModules(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, source := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(source), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestImports(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"lib/math.mk": `
+			let square = fn(x) { x * x };
+			export let cube = fn(x) { x * square(x) };
+			export let [one, two] = [1, 2];
+			export struct Point { x, y }
+			export class Square { area(x) { square(x) } }
+		`,
+		"lib/greeting.mk": `
+			import "math" as m;
+			export let greet = fn(name) { "hello " + name };
+			export let four = m.cube(1) + 3;
+		`,
+		"counter.mk": `
+			export let loaded = 1;
+		`,
+		"main.mk": `
+			import "lib/math.mk" as math;
+			import { greet, four } from "lib/greeting";
+			import "counter";
+			import "counter" as again;
+			let result = [math.cube(2), math["one"], greet("monkey"), four, counter.loaded];
+		`,
+	})
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`import "lib/math" as math; math["cube"](3)`, 27},
+		{`import "lib/math" as math; math.two`, 2},
+		{`import { one, two } from "lib/math"; one + two`, 3},
+		{`import { Point } from "lib/math"; Point(1, 2)`, expectedStruct{"Point", map[string]interface{}{"x": 1, "y": 2}}},
+		{`import "lib/math" as math; math.Square().area(3)`, 9},
+		{`import "lib/math" as math; math.square`, expectedError("module \"" + filepath.Join(dir, "lib/math.mk") + "\" has no export square")},
+		{`import { square } from "lib/math";`, expectedError("module \"lib/math\" has no export square")},
+		{`import "lib/greeting" as g; g.four`, 4},
+		{`import "counter"; counter.loaded`, 1},
+		{`import "missing" as m;`, expectedError("cannot find module \"missing.mk\"")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			Modules = NewModuleLoader()
+			env := object.NewModuleEnvironment(filepath.Join(dir, "test.mk"))
+
+			evaluated := Eval(testParseProgram(t, tt.input), env)
+			if evaluated == nil {
+				t.Fatalf("no result for %q", tt.input)
+			}
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+
+	Modules = NewModuleLoader()
+	evaluated := RunFile(filepath.Join(dir, "main.mk"))
+	if isError(evaluated) {
+		t.Fatalf("unexpected error running main.mk: %s", evaluated.Inspect())
+	}
+
+	if len(Modules.modules) != 3 {
+		t.Errorf("modules should only be loaded once. got %d modules", len(Modules.modules))
+	}
+}
+
+func TestModuleSearchPaths(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"vendor/util.mk": `export let answer = 42;`,
+		"app/util.mk":    `export let answer = 1;`,
+		"app/main.mk":    `import { answer } from "util"; answer`,
+		"other/main.mk":  `import { answer } from "util"; answer`,
+	})
+
+	Modules = NewModuleLoader()
+	Modules.SearchPaths = []string{filepath.Join(dir, "vendor")}
+
+	testIntegerObject(t, RunFile(filepath.Join(dir, "app/main.mk")), 1)
+	testIntegerObject(t, RunFile(filepath.Join(dir, "other/main.mk")), 42)
+}
+
+func TestImportErrors(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"a.mk":      `import "b"; 1`,
+		"b.mk":      `import "c"; 1`,
+		"c.mk":      `import "a"; 1`,
+		"self.mk":   `import "self"; 1`,
+		"broken.mk": `let x 5;`,
+		"fails.mk":  `export let x = 1; x()`,
+		"main.mk":   `import "fails"; 1`,
+		"undef.mk":  `let f = fn() { missing }; 1`,
+	})
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"a.mk", "import cycle detected: a.mk -> b.mk -> c.mk -> a.mk"},
+		{"self.mk", "import cycle detected: self.mk -> self.mk"},
+		{"broken.mk", "cannot parse module \"" + filepath.Join(dir, "broken.mk") + "\": expected token =, got {INTEGER 5}"},
+		{"main.mk", "not a function: integer"},
+		{"undef.mk", "cannot resolve module \"" + filepath.Join(dir, "undef.mk") + "\": identifier not found: missing"},
+	}
+
+	for _, tt := range tests {
+		Modules = NewModuleLoader()
+		evaluated := RunFile(filepath.Join(dir, tt.file))
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("%s: object is not Error. got=%T (%+v)", tt.file, evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Message != tt.expected {
+			t.Errorf("%s: wrong error message. want=%q, got=%q", tt.file, tt.expected, errObj.Message)
+		}
+	}
+
+	Modules = NewModuleLoader()
+	errObj := RunFile(filepath.Join(dir, "main.mk")).(*object.Error)
+	if len(errObj.Stack) != 2 || errObj.Stack[0] != "<module fails.mk>" || errObj.Stack[1] != "<module main.mk>" {
+		t.Errorf("wrong stack for error in imported module. got=%v", errObj.Stack)
+	}
+}
+
+// Modules imported by concurrent tasks should only be evaluated once, with the
+// tasks that lose the race waiting for the first to finish. This should be run
+// with -race.
+func TestConcurrentImports(t *testing.T) {
+	Modules = NewModuleLoader()
+
+	evaluated := testEval(`
+		let f = fn(n) { import "std/math" as m; [m, m.clamp(n, 2, 5)] };
+		map(map(collect(range(8)), fn(n) { spawn f(n) }), await)
+	`)
+	results, ok := evaluated.(*object.Array)
+	if !ok || len(results.Elements) != 8 {
+		t.Fatalf("wrong result. got=%s", evaluated.Inspect())
+	}
+
+	module := results.Elements[0].(*object.Array).Elements[0]
+	for i, result := range results.Elements {
+		elements := result.(*object.Array).Elements
+		if elements[0] != module {
+			t.Errorf("task %d imported a different module", i)
+		}
+		expected := int64(i)
+		if expected < 2 {
+			expected = 2
+		} else if expected > 5 {
+			expected = 5
+		}
+		testIntegerObject(t, elements[1], expected)
+	}
+
+	dir := writeModules(t, map[string]string{
+		"a.mk": `import "b"; 1`,
+		"b.mk": `import "a"; 1`,
+	})
+	Modules = NewModuleLoader()
+	env := object.NewModuleEnvironment(filepath.Join(dir, "test.mk"))
+
+	// However the imports interleave, both tasks see the cycle rather than
+	// waiting for each other.
+	evaluated = Eval(testParseProgram(t, `
+		let a = spawn fn() { import "a"; 1 }();
+		let b = spawn fn() { import "b"; 1 }();
+		let message = fn(task) { try { await(task) } catch (e) { e["message"] } };
+		[message(a), message(b)]
+	`), env)
+	for _, result := range evaluated.(*object.Array).Elements {
+		if !strings.HasPrefix(result.Inspect(), "import cycle detected: ") {
+			t.Errorf("expected an import cycle error. got=%s", result.Inspect())
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"sync"
+)
+
+// operatorMethods maps the infix operators that classes can overload to the names
+// of the methods implementing them. The method is looked up on the left operand
+// and called with the right operand. `!=` is the negation of `__eq__`.
+var operatorMethods = map[string]string{
+	"+":  "__add__",
+	"-":  "__sub__",
+	"*":  "__mul__",
+	"/":  "__div__",
+	"|":  "__or__",
+	"&":  "__and__",
+	"<":  "__lt__",
+	">":  "__gt__",
+	"==": "__eq__",
+}
+
+func init() {
+	object.InspectInstance = inspectInstance
+	object.EqualInstances = equalInstances
+}
+
+// evalOperatorMethod applies the method overloading operator for left, reporting
+// false if left isn't an instance of a class that defines one.
+func evalOperatorMethod(operator string, left, right object.Object) (object.Object, bool) {
+	if operator == "!=" {
+		result, ok := evalOperatorMethod("==", left, right)
+		if !ok || isError(result) {
+			return result, ok
+		}
+		return boolToBooleanObject(!isTruthy(result)), true
+	}
+
+	name, ok := operatorMethods[operator]
+	if !ok {
+		return nil, false
+	}
+	return callSpecialMethod(left, name, right)
+}
+
+// callSpecialMethod calls the method called name on obj, reporting false if obj
+// isn't an instance of a class that defines it.
+func callSpecialMethod(obj object.Object, name string, args ...object.Object) (object.Object, bool) {
+	instance, ok := obj.(*object.Instance)
+	if !ok {
+		return nil, false
+	}
+
+	method, definedBy, ok := instance.Class.FindMethod(name)
+	if !ok {
+		return nil, false
+	}
+	return applyFunction(bindMethod(instance, method, definedBy), args), true
+}
+
+// inspecting holds the instances whose __inspect__ method is running, so that an
+// instance inspected again from within it (such as by `throw self`) falls back to
+// the default format instead of recursing forever.
+var inspecting = struct {
+	sync.Mutex
+	instances map[*object.Instance]bool
+}{instances: make(map[*object.Instance]bool)}
+
+// inspectInstance formats instance with its __inspect__ method, falling back to
+// the default format if the method doesn't return a string.
+func inspectInstance(instance *object.Instance) (string, bool) {
+	inspecting.Lock()
+	if inspecting.instances[instance] {
+		inspecting.Unlock()
+		return "", false
+	}
+	inspecting.instances[instance] = true
+	inspecting.Unlock()
+
+	defer func() {
+		inspecting.Lock()
+		delete(inspecting.instances, instance)
+		inspecting.Unlock()
+	}()
+
+	result, ok := callSpecialMethod(instance, "__inspect__")
+	if !ok {
+		return "", false
+	}
+
+	switch result := result.(type) {
+	case *object.String:
+		return result.Value, true
+	case *object.Error:
+		return result.Inspect(), true
+	default:
+		return "", false
+	}
+}
+
+// equalInstances compares instance with other using its __eq__ method, so that
+// `in`, uniq and patterns agree with `==`.
+func equalInstances(instance *object.Instance, other object.Object) (bool, *object.Error) {
+	result, ok := callSpecialMethod(instance, "__eq__", other)
+	if !ok {
+		return false, nil
+	}
+	if err, ok := result.(*object.Error); ok {
+		return false, err
+	}
+	return isTruthy(result), nil
+}
@@ -0,0 +1,72 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestOperatorOverloading(t *testing.T) {
+	vector := `
+		class Vec {
+			init(x, y) { self.x = x; self.y = y }
+			__add__(other) { Vec(self.x + other.x, self.y + other.y) }
+			__sub__(other) { Vec(self.x - other.x, self.y - other.y) }
+			__mul__(k) { Vec(self.x * k, self.y * k) }
+			__neg__() { Vec(-self.x, -self.y) }
+			__eq__(other) { [self.x, self.y] == [other.x, other.y] }
+			__lt__(other) { self.x < other.x }
+			__index__(i) { [self.x, self.y][i] }
+			__setindex__(i, value) { if (i == 0) { self.x = value } else { self.y = value } }
+		}
+	`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{vector + "Vec(1, 2)", expectedInstance{"Vec", map[string]interface{}{"x": 1, "y": 2}}},
+		{vector + "Vec(1, 2) + Vec(3, 4)", expectedInstance{"Vec", map[string]interface{}{"x": 4, "y": 6}}},
+		{vector + "Vec(1, 2) - Vec(3, 4)", expectedInstance{"Vec", map[string]interface{}{"x": -2, "y": -2}}},
+		{vector + "Vec(1, 2) * 3", expectedInstance{"Vec", map[string]interface{}{"x": 3, "y": 6}}},
+		{vector + "-Vec(1, 2)", expectedInstance{"Vec", map[string]interface{}{"x": -1, "y": -2}}},
+		{vector + "Vec(1, 2) == Vec(1, 2)", true},
+		{vector + "Vec(1, 2) != Vec(1, 2)", false},
+		{vector + "Vec(1, 2) != Vec(2, 2)", true},
+		{vector + "Vec(1, 2) < Vec(2, 0)", true},
+		{vector + "Vec(1, 2) in [Vec(0, 0), Vec(1, 2)]", true},
+		{vector + "Vec(1, 2) in (Vec(0, 0),)", false},
+		{vector + "len(uniq([Vec(1, 2), Vec(1, 2), Vec(2, 1)]))", 2},
+		{vector + "[Vec(1, 2)] == [Vec(1, 2)]", true},
+		{"class N { init(n) { self.n = n } __eq__(other) { self.n == other } }; match (N(5)) { 4 => 4, 5 => 5, _ => 0 }", 5},
+		{"class A {}; let a = A(); [a in [a], A() in [a]]", []interface{}{true, false}},
+		{vector + "sort([Vec(3, 0), Vec(1, 0), Vec(2, 0)])", []interface{}{expectedInstance{"Vec", map[string]interface{}{"x": 1, "y": 0}}, expectedInstance{"Vec", map[string]interface{}{"x": 2, "y": 0}}, expectedInstance{"Vec", map[string]interface{}{"x": 3, "y": 0}}}},
+		{vector + "Vec(1, 2)[1]", 2},
+		{vector + "let v = Vec(1, 2); v[0] = 5; v", expectedInstance{"Vec", map[string]interface{}{"x": 5, "y": 2}}},
+		{vector + "Vec(1, 2) / 2", expectedError("type mismatch: INSTANCE / integer")},
+		{vector + "Vec(1, 2) > Vec(0, 0)", expectedError("unknown operator: INSTANCE > INSTANCE")},
+		{vector + "Vec(1, 2) + 1", expectedError("member access not supported: integer.x")},
+		{"class A {}; A() + A()", expectedError("unknown operator: INSTANCE + INSTANCE")},
+		{"class A {}; A() == A()", false},
+		{"class A {}; -A()", expectedError("unknown operator: -INSTANCE")},
+		{"class A {}; A()[0]", expectedError("index operator not supported: INSTANCE")},
+		{"class A {}; let a = A(); a[0] = 1", expectedError("index operator not supported: INSTANCE")},
+		{`class Tag { init(name) { self.name = name } __inspect__() { "<" + self.name + ">" } }; [Tag("a"), Tag("b")]`, []interface{}{inspected{object.INSTANCE_OBJ, "<a>"}, inspected{object.INSTANCE_OBJ, "<b>"}}},
+		{"class A { __inspect__() { 1 } }; A()", inspected{object.INSTANCE_OBJ, "A{}"}},
+		{"class A { __add__(x) { throw \"no\" } }; A() + 1", expectedError("no")},
+		{"class A { __inspect__() { throw self } }; A()", inspected{object.INSTANCE_OBJ, "ERROR: A{}"}},
+		{"class A { __inspect__() { match (self) { 0 => 0 } } }; A()", inspected{object.INSTANCE_OBJ, "ERROR: no match arm matched A{}"}},
+		// errors raised by __eq__ aren't mistaken for inequality
+		{"class E { __eq__(other) { throw \"eq\" } }; E() == 1", expectedError("eq")},
+		{"class E { __eq__(other) { throw \"eq\" } }; 1 in [E()]", expectedError("eq")},
+		{"class E { __eq__(other) { throw \"eq\" } }; uniq([E(), E()])", expectedError("eq")},
+		{"class E { __eq__(other) { throw \"eq\" } }; [E()] == [E()]", expectedError("eq")},
+		{"class E { __eq__(other) { throw \"eq\" } }; match (E()) { 1 => 1, _ => 2 }", expectedError("eq")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+func evalMatchExpression(me *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Eval(me.Subject, env)
+	if isError(subject) {
+		return subject
+	}
+
+	for _, arm := range me.Arms {
+		// Each arm gets its own scope so that bindings from arms that failed to
+		// match (or whose guard failed) don't leak into the one that's evaluated.
+		armEnv := object.NewFrame(env, arm.Locals)
+
+		mismatch, err := matchPattern(arm.Pattern, subject, armEnv)
+		if err != nil {
+			return err
+		}
+		if mismatch != nil {
+			continue
+		}
+
+		if arm.Guard != nil {
+			guard := Eval(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+
+		return Eval(arm.Body, armEnv)
+	}
+
+	return newError("no match arm matched %s", subject.Inspect())
+}
+
+// bindPattern matches value against pattern, setting any identifiers bound by the
+// pattern in env. If value doesn't have the shape described by the pattern then
+// an error describing the mismatch is returned.
+func bindPattern(pattern ast.Pattern, value object.Object, env *object.Environment) *object.Error {
+	mismatch, err := matchPattern(pattern, value, env)
+	if err != nil {
+		return err
+	}
+	return mismatch
+}
+
+// matchPattern matches value against pattern like bindPattern, but tells the two
+// ways of failing apart: mismatch describes why value doesn't have the shape of
+// the pattern, while err is an error raised while matching (such as by evaluating
+// a literal in the pattern), which match expressions don't recover from.
+func matchPattern(pattern ast.Pattern, value object.Object, env *object.Environment) (mismatch, err *object.Error) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		if pattern.Value == "_" {
+			return nil, nil
+		}
+		if pattern.Resolved {
+			env.SetAt(pattern.Depth, pattern.Slot, value)
+			return nil, nil
+		}
+		return nil, define(env, pattern.Value, value)
+	case *ast.ArrayPattern:
+		return matchArrayPattern(pattern, value, env)
+	case *ast.HashPattern:
+		return matchHashPattern(pattern, value, env)
+	case *ast.ConstructorPattern:
+		return matchConstructorPattern(pattern, value, env)
+	default:
+		literal := Eval(pattern, env)
+		if isError(literal) {
+			return nil, literal.(*object.Error)
+		}
+		// The value is compared on the left, as in `value == literal`, so that
+		// instances are compared with their __eq__ method.
+		equal, err := object.Equal(value, literal)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			return newError("%s does not match %s", value.Inspect(), pattern.String()), nil
+		}
+		return nil, nil
+	}
+}
+
+// matchArrayPattern destructures arrays and tuples. The rest of a tuple is bound
+// as a tuple.
+func matchArrayPattern(pattern *ast.ArrayPattern, value object.Object, env *object.Environment) (mismatch, err *object.Error) {
+	var elements []object.Object
+	switch value := value.(type) {
+	case *object.Array:
+		elements = value.Elements
+	case *object.Tuple:
+		elements = value.Elements
+	default:
+		return newError("cannot destructure %s as array", value.Type()), nil
+	}
+
+	size := len(elements)
+	if size < len(pattern.Elements) || (pattern.Rest == nil && size != len(pattern.Elements)) {
+		return newError("cannot destructure array of length %d into %d elements", size, len(pattern.Elements)), nil
+	}
+
+	for i, element := range pattern.Elements {
+		if mismatch, err := matchPattern(element, elements[i], env); mismatch != nil || err != nil {
+			return mismatch, err
+		}
+	}
+
+	if pattern.Rest != nil {
+		rest := make([]object.Object, size-len(pattern.Elements))
+		copy(rest, elements[len(pattern.Elements):])
+
+		if value.Type() == object.TUPLE_OBJ {
+			return matchPattern(pattern.Rest, &object.Tuple{Elements: rest}, env)
+		}
+		return matchPattern(pattern.Rest, &object.Array{Elements: rest}, env)
+	}
+
+	return nil, nil
+}
+
+func matchHashPattern(pattern *ast.HashPattern, value object.Object, env *object.Environment) (mismatch, err *object.Error) {
+	hash, ok := value.(*object.Hash)
+	if !ok {
+		return newError("cannot destructure %s as hash", value.Type()), nil
+	}
+
+	matched := make(map[object.HashKey]bool)
+
+	for _, pair := range pattern.Pairs {
+		key := Eval(pair.Key, env)
+		if isError(key) {
+			return nil, key.(*object.Error)
+		}
+
+		hashKey, ok := object.HashKeyOf(key)
+		if !ok {
+			return nil, newError("object of type %s cannot be used as a hash key", key.Type())
+		}
+
+		hashPair, ok := hash.Pairs[hashKey]
+		if !ok {
+			return newError("hash has no key %s", key.Inspect()), nil
+		}
+
+		if mismatch, err := matchPattern(pair.Value, hashPair.Value, env); mismatch != nil || err != nil {
+			return mismatch, err
+		}
+		matched[hashKey] = true
+	}
+
+	if pattern.Rest != nil {
+		rest := object.NewHash()
+		for _, hashPair := range hash.OrderedPairs() {
+			hashKey := hashPair.Key.(object.Hashable).HashKey()
+			if !matched[hashKey] {
+				rest.Set(hashKey, hashPair)
+			}
+		}
+
+		return matchPattern(pattern.Rest, rest, env)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,94 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestMatchExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`match (1) { 0 => "zero", 1 => "one", _ => "many" }`, "one"},
+		{`match (5) { 0 => "zero", 1 => "one", _ => "many" }`, "many"},
+		{`match (-1) { -1 => "negative", _ => "other" }`, "negative"},
+		{`match ("b") { "a" => 1, "b" => 2 }`, 2},
+		{`match (true) { false => 1, true => 2 }`, 2},
+		{`match (null) { null => 1, _ => 2 }`, 1},
+		{`match (7) { n => n * 2 }`, 14},
+		{`match (7) { n if n > 10 => 1, n if n > 5 => 2, _ => 3 }`, 2},
+		{`match ([1, 2, 3]) { [] => 0, [a] => a, [a, b] => a + b, [a, b, c] => a + b + c }`, 6},
+		{`match ([1, 2, 3]) { [a, ...rest] => len(rest) }`, 2},
+		{`match ([]) { [a, ...rest] => 1, [...rest] => 2 }`, 2},
+		{`match ([1, [2, 3]]) { [a, [b, c]] => a + b + c }`, 6},
+		{`match ([1, 2]) { [1, x] => x, _ => 0 }`, 2},
+		{`match ([3, 2]) { [1, x] => x, _ => 0 }`, 0},
+		{`match ({"x": 1, "y": 2}) { {x, y} => x + y }`, 3},
+		{`match ({"x": 1}) { {x, y} => 1, {x} => 2 }`, 2},
+		{`match ({"kind": "sq", "size": 3}) { {"kind": "circle"} => 0, {"kind": "sq", size: s} => s * s }`, 9},
+		{`match ({"a": 1, "b": 2, "c": 3}) { {a, ...rest} => rest["c"] + (rest["a"] ?? 10) }`, 13},
+		{`match (1) { x if y => 1 }`, expectedError("identifier not found: y")},
+		{`match (5) { 0 => 1 }`, expectedError("no match arm matched 5")},
+		{`match (1) { x => { let y = x + 1; y * 2 } }`, 4},
+		{`match (1) { x if x > 5 => 1, _ => x }`, expectedError("identifier not found: x")},
+		{`let f = fn(x) { match (x) { 0 => { return 10; } }; 20 }; f(0)`, 10},
+		{`match (1) { _ => ({"a": 1}) }["a"]`, 1},
+		// errors raised while matching aren't mistaken for arms that don't match
+		{`match (1) { missing(x) => x, _ => 0 }`, expectedError("identifier not found: missing")},
+		{`match ([1]) { [missing(x)] => x, _ => 0 }`, expectedError("identifier not found: missing")},
+		{`match ({"a": 1}) { {"a": missing(x)} => x, _ => 0 }`, expectedError("identifier not found: missing")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			testObject(t, testEval(tt.input), tt.expected)
+		})
+	}
+}
+
+func TestDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let [a, b] = [1, 2]; a + b", 3},
+		{"let [a, b, ...rest] = [1, 2, 3, 4]; a + b + len(rest)", 5},
+		{"let [a, ...rest] = [1]; len(rest)", 0},
+		{"let [_, b] = [1, 2]; b", 2},
+		{"let [[a, b], c] = [[1, 2], 3]; a + b + c", 6},
+		{`let {name, age} = {"name": "monkey", "age": 5}; age`, 5},
+		{`let {age: years} = {"name": "monkey", "age": 5}; years`, 5},
+		{`let {name, ...rest} = {"name": "monkey", "age": 5}; rest["age"]`, 5},
+		{`let {point: [x, y]} = {"point": [3, 4]}; x * y`, 12},
+		{"let f = fn([a, b]) { a * b }; f([3, 4])", 12},
+		{`let f = fn({x, y}, z) { x + y + z }; f({"x": 1, "y": 2}, 3)`, 6},
+		{"let f = fn([head, ...tail]) { len(tail) }; f([1, 2, 3])", 2},
+		{"let [a, b] = 5;", "cannot destructure integer as array"},
+		{"let [a, b] = [1];", "cannot destructure array of length 1 into 2 elements"},
+		{"let [a] = [1, 2];", "cannot destructure array of length 2 into 1 elements"},
+		{"let {a} = [1];", "cannot destructure ARRAY as hash"},
+		{`let {a} = {"b": 1};`, "hash has no key a"},
+		{"let f = fn([a, b]) { a }; f(1)", "cannot destructure integer as array"},
+		{"let f = fn(a, b) { a }; f(1)", "wrong number of arguments. got=1, want=2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+
+			switch expected := tt.expected.(type) {
+			case int:
+				testIntegerObject(t, evaluated, int64(expected))
+			case string:
+				errObj, ok := evaluated.(*object.Error)
+				if !ok {
+					t.Fatalf("object is not Error. got=%T (%+v)", evaluated, evaluated)
+				}
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. want=%q, got=%q", expected, errObj.Message)
+				}
+			}
+		})
+	}
+}
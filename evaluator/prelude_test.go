@@ -0,0 +1,93 @@
+package evaluator
+
+import (
+	"fmt"
+	"monkey-interpreter/lexer"
+	"monkey-interpreter/object"
+	"monkey-interpreter/parser"
+	"sync"
+	"testing"
+)
+
+// Scripts evaluated concurrently against a shared frozen prelude should only see
+// their own definitions. This should be run with -race.
+func TestSharedPrelude(t *testing.T) {
+	prelude := object.NewEnvironment()
+	Eval(parser.New(lexer.New(`
+		let base = 100;
+		let add = fn(a, b) { a + b };
+		let sumTo = fn(n) { reduce(collect(range(n + 1)), 0, add) };
+		class Counter {
+			init(start) { self.count = start }
+			increment() { self.count = self.count + 1 }
+		}
+	`)).ParseProgram(), prelude)
+	prelude.Freeze()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			input := fmt.Sprintf(`
+				import "std/math" as m;
+				let base = base + %d;
+				let counter = Counter(base);
+				counter.increment();
+				let task = spawn sumTo(%d);
+				counter.count + await(task) + m.sign(%d)
+			`, i, i, i)
+			env := object.NewEnclosingEnvironment(prelude)
+			evaluated := Eval(parser.New(lexer.New(input)).ParseProgram(), env)
+
+			sign := 0
+			if i > 0 {
+				sign = 1
+			}
+			expected := fmt.Sprint(100 + i + 1 + i*(i+1)/2 + sign)
+			if evaluated.Inspect() != expected {
+				t.Errorf("wrong result for script %d. want=%q, got=%q", i, expected, evaluated.Inspect())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if base, _ := prelude.Get("base"); base.Inspect() != "100" {
+		t.Errorf("expected prelude to be unchanged, got base=%s", base.Inspect())
+	}
+}
+
+func TestEvalInFrozenEnvironment(t *testing.T) {
+	env := object.NewEnvironment()
+	env.Freeze()
+
+	evaluated := Eval(parser.New(lexer.New("let x = 1; x")).ParseProgram(), env)
+	expected := "cannot evaluate a program in a frozen environment"
+	if !isError(evaluated) || evaluated.(*object.Error).Message != expected {
+		t.Errorf("wrong result. want=%q, got=%q", expected, evaluated.Inspect())
+	}
+
+	// Statements evaluated on their own rather than as a program should report
+	// an error rather than panicking.
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1;", "cannot define x in a frozen environment"},
+		{"let [a, b] = [1, 2];", "cannot define a in a frozen environment"},
+		{"struct Point { x, y }", "cannot define Point in a frozen environment"},
+		{"class Counter {}", "cannot define Counter in a frozen environment"},
+		{"enum Color { Red }", "cannot define Color in a frozen environment"},
+		{`import "std/math" as m;`, "cannot define m in a frozen environment"},
+		{`import { sign } from "std/math";`, "cannot define sign in a frozen environment"},
+	}
+
+	for _, tt := range tests {
+		statement := parser.New(lexer.New(tt.input)).ParseProgram().Statements[0]
+		evaluated := Eval(statement, env)
+		if !isError(evaluated) || evaluated.(*object.Error).Message != tt.expected {
+			t.Errorf("wrong result for %q. want=%q, got=%v", tt.input, tt.expected, evaluated)
+		}
+	}
+}
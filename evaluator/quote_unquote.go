@@ -0,0 +1,87 @@
+package evaluator
+
+import (
+	"fmt"
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+	"monkey-interpreter/token"
+)
+
+// quote returns node without evaluating it, except for any calls to unquote()
+// within it, which are evaluated and have their results spliced into the tree.
+func quote(node ast.Node, env *object.Environment) object.Object {
+	var err *object.Error
+
+	node = ast.Modify(node, func(node ast.Node) ast.Node {
+		if err != nil || !isUnquoteCall(node) {
+			return node
+		}
+
+		call := node.(*ast.CallExpression)
+		if len(call.Arguments) != 1 {
+			err = newError("wrong number of arguments to `unquote`. got=%d, want=1", len(call.Arguments))
+			return node
+		}
+
+		unquoted := Eval(call.Arguments[0], env)
+		if isError(unquoted) {
+			err = unquoted.(*object.Error)
+			return node
+		}
+
+		converted, ok := convertObjectToASTNode(unquoted)
+		if !ok {
+			err = newError("cannot unquote value of type %s", unquoted.Type())
+			return node
+		}
+		return converted
+	})
+
+	if err != nil {
+		return err
+	}
+	return &object.Quote{Node: node}
+}
+
+func isUnquoteCall(node ast.Node) bool {
+	call, ok := node.(*ast.CallExpression)
+	if !ok {
+		return false
+	}
+
+	identifier, ok := call.Function.(*ast.Identifier)
+	return ok && identifier.Value == "unquote"
+}
+
+func convertObjectToASTNode(obj object.Object) (ast.Node, bool) {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		t := token.Token{Type: token.INT, Value: fmt.Sprintf("%d", obj.Value)}
+		return &ast.Integer{Token: t, Value: obj.Value}, true
+	case *object.Boolean:
+		t := token.Token{Type: token.FALSE, Value: "false"}
+		if obj.Value {
+			t = token.Token{Type: token.TRUE, Value: "true"}
+		}
+		return &ast.Boolean{Token: t, Value: obj.Value}, true
+	case *object.String:
+		t := token.Token{Type: token.STRING, Value: obj.Value}
+		return &ast.String{Token: t, Value: obj.Value}, true
+	case *object.Null:
+		return &ast.Null{Token: token.Token{Type: token.NULL, Value: "null"}}, true
+	case *object.Array:
+		array := &ast.Array{Token: token.Token{Type: token.LBRACKET, Value: "["}}
+		for _, element := range obj.Elements {
+			node, ok := convertObjectToASTNode(element)
+			if !ok {
+				return nil, false
+			}
+			array.Elements = append(array.Elements, node)
+		}
+		return array, true
+	case *object.Quote:
+		return obj.Node, true
+	default:
+		return nil, false
+	}
+}
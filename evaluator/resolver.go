@@ -0,0 +1,360 @@
+package evaluator
+
+import (
+	"fmt"
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+// Resolve works out where each local variable referenced in program is stored,
+// so that it can be read from a slot of the frame it's bound in rather than
+// looked up by name in each enclosing environment, and marks the calls made in
+// tail position so that they don't grow the stack. Variables are local to the
+// function, method, for loop iteration, try, catch or finally block or match arm
+// they're bound in. Top-level names are still looked up by name, since env can gain
+// more of them at runtime (such as from later lines in the REPL).
+//
+// An error is returned for each name that isn't defined in an enclosing scope,
+// the top level of program, env or the builtins. Programs must be resolved
+// before they're evaluated rather than while they're being evaluated, since the
+// results are stored in the tree.
+func Resolve(program *ast.AST, env *object.Environment) []error {
+	r := &resolver{
+		env:       env,
+		addresses: make(map[*ast.Identifier]address),
+		calls:     make(map[*ast.CallExpression]int),
+		tailCalls: make(map[*ast.CallExpression]int),
+		undefined: make(map[string]bool),
+	}
+
+	r.push()
+	for _, statement := range program.Statements {
+		r.resolve(statement)
+	}
+	r.pop()
+	r.markTails()
+
+	return r.errors
+}
+
+type resolver struct {
+	env *object.Environment
+	// scopes are the scopes enclosing the node being resolved, starting with the
+	// top level of the program.
+	scopes []*scope
+
+	// addresses records where each identifier was resolved to, since macros can
+	// splice the same node into more than one place. Identifiers resolved to
+	// different places are left to be looked up by name.
+	addresses map[*ast.Identifier]address
+	// calls counts the times each call is resolved, and tailCalls the times
+	// it's found in tail position, for the same reason.
+	calls     map[*ast.CallExpression]int
+	tailCalls map[*ast.CallExpression]int
+	undefined map[string]bool
+	errors    []error
+}
+
+type scope struct {
+	locals []string
+	// deferred resolves the functions defined in the scope once the rest of it
+	// has been resolved, since they can refer to names defined after them.
+	deferred []func()
+}
+
+type address struct {
+	resolved    bool
+	depth, slot int
+}
+
+// conflicting is recorded for identifiers resolved to more than one address.
+var conflicting = address{depth: -1}
+
+func (s *scope) slot(name string) int {
+	for i, local := range s.locals {
+		if local == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *resolver) push(locals ...string) {
+	r.scopes = append(r.scopes, &scope{locals: locals})
+}
+
+// pop resolves the functions deferred by the innermost scope and then ends it,
+// returning the names it binds in slot order.
+func (r *resolver) pop() []string {
+	current := r.scopes[len(r.scopes)-1]
+	for i := 0; i < len(current.deferred); i++ {
+		current.deferred[i]()
+	}
+
+	r.scopes = r.scopes[:len(r.scopes)-1]
+	return current.locals
+}
+
+func (r *resolver) declare(name string) {
+	current := r.scopes[len(r.scopes)-1]
+	if name != "_" && current.slot(name) == -1 {
+		current.locals = append(current.locals, name)
+	}
+}
+
+// bind declares identifier in the innermost scope and resolves it, so that the
+// value it's bound to is stored straight into its slot.
+func (r *resolver) bind(identifier *ast.Identifier) {
+	if identifier.Value == "_" {
+		return
+	}
+	r.declare(identifier.Value)
+	r.resolveIdentifier(identifier)
+}
+
+func (r *resolver) resolve(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.BlockStatement:
+		for _, statement := range node.Statements {
+			r.resolve(statement)
+		}
+	case *ast.ExpressionStatement:
+		r.resolve(node.Expression)
+	case *ast.LetStatement:
+		r.resolve(node.Value)
+		r.resolvePattern(node.Name)
+	case *ast.ReturnStatement:
+		r.resolve(node.Value)
+	case *ast.ThrowStatement:
+		r.resolve(node.Value)
+	case *ast.ExportStatement:
+		r.resolve(node.Statement)
+	case *ast.ImportStatement:
+		for _, name := range node.Names {
+			r.declare(name.Value)
+		}
+		if node.Names == nil {
+			r.declare(moduleAlias(node))
+		}
+	case *ast.StructStatement:
+		r.declare(node.Name.Value)
+	case *ast.EnumStatement:
+		r.declare(node.Name.Value)
+	case *ast.ClassStatement:
+		r.resolveClass(node)
+	case *ast.Identifier:
+		r.resolveIdentifier(node)
+	case *ast.Function:
+		r.resolveFunction(node.Parameters, node.Body, &node.Locals)
+		// Generators run their bodies themselves rather than through
+		// applyFunction, so they don't make tail calls.
+		if !node.Generator {
+			r.markTailCalls(node.Body, true)
+		}
+	case *ast.Array:
+		r.resolveExpressions(node.Elements)
+	case *ast.Tuple:
+		r.resolveExpressions(node.Elements)
+	case *ast.Set:
+		r.resolveExpressions(node.Elements)
+	case *ast.Hash:
+		for _, pair := range node.Pairs {
+			r.resolve(pair.Key)
+			r.resolve(pair.Value)
+		}
+	case *ast.PrefixExpression:
+		r.resolve(node.Right)
+	case *ast.InfixExpression:
+		r.resolve(node.Left)
+		r.resolve(node.Right)
+	case *ast.IfExpression:
+		r.resolve(node.Condition)
+		r.resolve(node.Consequence)
+		if node.ElseIf != nil {
+			r.resolve(node.ElseIf)
+		}
+		if node.Alternative != nil {
+			r.resolve(node.Alternative)
+		}
+	case *ast.ConditionalExpression:
+		r.resolve(node.Condition)
+		r.resolve(node.Consequence)
+		r.resolve(node.Alternative)
+	case *ast.MatchExpression:
+		r.resolve(node.Subject)
+		for _, arm := range node.Arms {
+			r.push()
+			r.resolvePattern(arm.Pattern)
+			if arm.Guard != nil {
+				r.resolve(arm.Guard)
+			}
+			r.resolve(arm.Body)
+			arm.Locals = r.pop()
+		}
+	case *ast.TryExpression:
+		r.push()
+		r.resolve(node.Body)
+		node.BodyLocals = r.pop()
+		if node.Catch != nil {
+			r.push()
+			if node.CatchParam != nil {
+				r.resolvePattern(node.CatchParam)
+			}
+			r.resolve(node.Catch)
+			node.CatchLocals = r.pop()
+		}
+		if node.Finally != nil {
+			r.push()
+			r.resolve(node.Finally)
+			node.FinallyLocals = r.pop()
+		}
+	case *ast.ForExpression:
+		r.resolve(node.Iterable)
+		r.push()
+		for _, variable := range node.Variables {
+			r.resolvePattern(variable)
+		}
+		r.resolve(node.Body)
+		node.Locals = r.pop()
+	case *ast.CallExpression:
+		r.calls[node]++
+		// The argument of quote is a syntax tree rather than code to evaluate.
+		if identifier, ok := node.Function.(*ast.Identifier); ok && identifier.Value == "quote" {
+			return
+		}
+		r.resolve(node.Function)
+		r.resolveExpressions(node.Arguments)
+	case *ast.IndexExpression:
+		r.resolve(node.Left)
+		r.resolve(node.Index)
+	case *ast.SliceExpression:
+		r.resolve(node.Left)
+		if node.Low != nil {
+			r.resolve(node.Low)
+		}
+		if node.High != nil {
+			r.resolve(node.High)
+		}
+	case *ast.MemberExpression:
+		r.resolve(node.Object)
+	case *ast.AssignExpression:
+		r.resolve(node.Target)
+		r.resolve(node.Value)
+	case *ast.YieldExpression:
+		if node.Value != nil {
+			r.resolve(node.Value)
+		}
+	case *ast.SpawnExpression:
+		r.resolve(node.Call)
+	}
+}
+
+func (r *resolver) resolveExpressions(expressions []ast.Expression) {
+	for _, expression := range expressions {
+		r.resolve(expression)
+	}
+}
+
+// resolvePattern declares the names bound by pattern and resolves the expressions
+// within it, in the order bindPattern evaluates them.
+func (r *resolver) resolvePattern(pattern ast.Pattern) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		r.bind(pattern)
+	case *ast.ArrayPattern:
+		for _, element := range pattern.Elements {
+			r.resolvePattern(element)
+		}
+		if pattern.Rest != nil {
+			r.bind(pattern.Rest)
+		}
+	case *ast.HashPattern:
+		for _, pair := range pattern.Pairs {
+			r.resolve(pair.Key)
+			r.resolvePattern(pair.Value)
+		}
+		if pattern.Rest != nil {
+			r.bind(pattern.Rest)
+		}
+	case *ast.ConstructorPattern:
+		r.resolve(pattern.Constructor)
+		for _, argument := range pattern.Arguments {
+			r.resolvePattern(argument)
+		}
+	default:
+		r.resolve(pattern)
+	}
+}
+
+// later calls resolve once the rest of the innermost scope has been resolved.
+func (r *resolver) later(resolve func()) {
+	current := r.scopes[len(r.scopes)-1]
+	current.deferred = append(current.deferred, resolve)
+}
+
+// resolveFunction resolves a function at the end of the scope it's defined in,
+// storing the names bound in its own scope in locals.
+func (r *resolver) resolveFunction(parameters []ast.Pattern, body *ast.BlockStatement, locals *[]string) {
+	r.later(func() {
+		r.push()
+		for _, parameter := range parameters {
+			r.resolvePattern(parameter)
+		}
+		r.resolve(body)
+		*locals = r.pop()
+	})
+}
+
+// resolveClass resolves each method of a class within a scope of the names bound
+// by bindMethod, which encloses the scope of the method itself.
+func (r *resolver) resolveClass(node *ast.ClassStatement) {
+	if node.Parent != nil {
+		r.resolve(node.Parent)
+	}
+	r.declare(node.Name.Value)
+
+	for _, method := range node.Methods {
+		r.markTailCalls(method.Body, true)
+
+		method := method
+		r.later(func() {
+			r.push(append([]string(nil), methodLocals...)...)
+			r.resolveFunction(method.Parameters, method.Body, &method.Locals)
+			r.pop()
+		})
+	}
+}
+
+func (r *resolver) resolveIdentifier(identifier *ast.Identifier) {
+	// The top-level scope isn't searched, since top-level names aren't stored
+	// in slots.
+	resolved := address{}
+	for i, depth := len(r.scopes)-1, 0; i > 0; i, depth = i-1, depth+1 {
+		if slot := r.scopes[i].slot(identifier.Value); slot != -1 {
+			resolved = address{resolved: true, depth: depth, slot: slot}
+			break
+		}
+	}
+
+	if previous, ok := r.addresses[identifier]; ok && previous != resolved {
+		resolved = conflicting
+	}
+	r.addresses[identifier] = resolved
+	identifier.Resolved, identifier.Depth, identifier.Slot = resolved.resolved, resolved.depth, resolved.slot
+
+	if resolved == (address{}) && !r.isGlobal(identifier.Value) && !r.undefined[identifier.Value] {
+		r.undefined[identifier.Value] = true
+		r.errors = append(r.errors, fmt.Errorf("identifier not found: %s", identifier.Value))
+	}
+}
+
+func (r *resolver) isGlobal(name string) bool {
+	if r.scopes[0].slot(name) != -1 {
+		return true
+	}
+	if _, ok := r.env.Get(name); ok {
+		return true
+	}
+	_, ok := builtins[name]
+	return ok
+}
@@ -0,0 +1,117 @@
+package evaluator
+
+import (
+	"fmt"
+	"monkey-interpreter/ast"
+	"monkey-interpreter/lexer"
+	"monkey-interpreter/object"
+	"monkey-interpreter/parser"
+	"strings"
+	"testing"
+)
+
+func TestResolveAddresses(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let x = 1; x", ""},
+		{"fn(a, b) { a + b }", "a@0:0 b@0:1"},
+		{"fn(a) { let b = a; fn(c) { [a, b, c] } }", "a@0:0 a@1:0 b@1:1 c@0:0"},
+		{"fn(a) { let a = a + 1; a }", "a@0:0 a@0:0"},
+		{"fn() { let f = fn() { g() }; let g = fn() { 1 } }", "g@1:1"},
+		{"fn(xs) { for (i, x in xs) { [i, x, xs] } }", "xs@0:0 i@0:0 x@0:1 xs@1:0"},
+		{"fn(e) { try { e } catch (e) { e } }", "e@1:0 e@0:0"},
+		{"fn() { try { let a = 1; a } catch (e) { let b = e; b } }", "a@0:0 e@0:0 b@0:1"},
+		{"fn(a) { try { a } finally { let b = a; b } }", "a@1:0 a@1:0 b@0:0"},
+		{"fn(v) { match (v) { [a, b] if a > b => a, c => [c, v] } }", "v@0:0 a@0:0 b@0:1 a@0:0 c@0:0 v@1:0"},
+		{"class A { f(x) { [self, x] } }", "self@1:0 x@0:0"},
+		{"fn(x) { quote(x) }", ""},
+		{"fn(x) { len(x) }", "x@0:0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			program := parser.New(lexer.New(tt.input)).ParseProgram()
+			Resolve(program, object.NewEnvironment())
+
+			addresses := make([]string, 0)
+			collect := func(node ast.Node) ast.Node {
+				if identifier, ok := node.(*ast.Identifier); ok && identifier.Resolved {
+					addresses = append(addresses, fmt.Sprintf("%s@%d:%d", identifier.Value, identifier.Depth, identifier.Slot))
+				}
+				return node
+			}
+			ast.Modify(program, collect)
+
+			if got := strings.Join(addresses, " "); got != tt.expected {
+				t.Errorf("wrong addresses. want=%q, got=%q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"let x = 1; fn() { x + len([]) }", nil},
+		{"let f = fn() { g() }; let g = fn() { f() }", nil},
+		{"if (false) { missing }", []string{"identifier not found: missing"}},
+		{"let f = fn() { a + b + a }", []string{"identifier not found: a", "identifier not found: b"}},
+		{"x; let x = 1", []string{"identifier not found: x"}},
+		{"fn() { let y = 1 }; y", []string{"identifier not found: y"}},
+		{"for (i in [1]) { }; i", []string{"identifier not found: i"}},
+		{"fn() { self }", []string{"identifier not found: self"}},
+		{`import { a } from "lib"; import "std/math"; fn() { [a, math] }`, nil},
+		{"struct P { x }; enum E { A }; class C extends P { }; [P, E, C, x]", []string{"identifier not found: x"}},
+		{"match (1) { Some(x) => x }", []string{"identifier not found: Some"}},
+		{"spawn f()", []string{"identifier not found: f"}},
+		{"quote(missing)", nil},
+		{"defined", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			env := object.NewEnvironment()
+			env.Set("defined", TRUE)
+
+			program := parser.New(lexer.New(tt.input)).ParseProgram()
+			errs := Resolve(program, env)
+
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if strings.Join(got, "; ") != strings.Join(tt.expected, "; ") {
+				t.Errorf("wrong errors. want=%q, got=%q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestResolvedEvaluation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let x = 1; let f = fn() { let x = x + 1; x }; f()", 2},
+		{"let f = fn(x) { let g = fn() { y }; let y = x * 2; g() }; f(3)", 6},
+		{"let f = fn(x) { let g = fn() { y }; let r = try { g() } catch (e) { e.message }; let y = 1; r }; f(3)", "identifier not found: y"},
+		{"let fs = map([1, 2, 3], fn(i) { fn() { i } }); map(fs, fn(f) { f() })", []interface{}{1, 2, 3}},
+		{"let f = fn(n) { if (n == 0) { return 0 }; n + f(n - 1) }; f(10)", 55},
+		{"let f = fn(xs) { let total = []; for (x in xs) { let total = push(total, x) }; total }; f([1, 2])", []interface{}{}},
+		{"let f = fn(v) { match (v) { [a] if a > 5 => a, [b] => b * 2 } }; [f([7]), f([1])]", []interface{}{7, 2}},
+		{"class A { init(x) { self.x = x } get() { self.x } }; class B extends A { get() { super.get() + 1 } }; B(1).get()", 2},
+		{"let gen = fn*(n) { for (i in range(n)) { yield i * n } }; collect(gen(3))", []interface{}{0, 3, 6}},
+		{"let f = fn() { missing }; 1", expectedError("identifier not found: missing")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
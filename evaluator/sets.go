@@ -0,0 +1,123 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+	"strings"
+)
+
+func evalSetLiteral(node *ast.Set, env *object.Environment) object.Object {
+	elements := evalExpressions(node.Elements, env)
+	if len(elements) > 0 && isError(elements[0]) {
+		return elements[0]
+	}
+
+	return newSet(elements)
+}
+
+// newSet returns a set of elements, or an error if one of them isn't hashable.
+func newSet(elements []object.Object) object.Object {
+	set := object.NewSet()
+	for _, element := range elements {
+		key, ok := object.HashKeyOf(element)
+		if !ok {
+			return newError("object of type %s cannot be used as a set element", element.Type())
+		}
+		set.Add(key, element)
+	}
+
+	return set
+}
+
+// evalSetInfixExpression implements union (|), intersection (&) and difference (-).
+// The elements of the result are ordered as they are in left, followed by any
+// elements only in right.
+func evalSetInfixExpression(operator string, left, right object.Object) object.Object {
+	leftSet, rightSet := left.(*object.Set), right.(*object.Set)
+	result := object.NewSet()
+
+	switch operator {
+	case "|":
+		for _, set := range []*object.Set{leftSet, rightSet} {
+			for _, element := range set.OrderedElements() {
+				key, _ := object.HashKeyOf(element)
+				result.Add(key, element)
+			}
+		}
+	case "&", "-":
+		for _, element := range leftSet.OrderedElements() {
+			key, _ := object.HashKeyOf(element)
+			if _, ok := rightSet.Elements[key]; ok == (operator == "&") {
+				result.Add(key, element)
+			}
+		}
+	case "==", "!=":
+		return evalEqualityExpression(operator, left, right)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	return result
+}
+
+// evalInExpression tests whether left is an element of a set, array or tuple, a
+// key of a hash, or a substring of a string.
+func evalInExpression(left, right object.Object) object.Object {
+	switch right := right.(type) {
+	case *object.Set:
+		key, ok := object.HashKeyOf(left)
+		if !ok {
+			return FALSE
+		}
+		_, ok = right.Elements[key]
+		return boolToBooleanObject(ok)
+	case *object.Hash:
+		key, ok := object.HashKeyOf(left)
+		if !ok {
+			return FALSE
+		}
+		_, ok = right.Pairs[key]
+		return boolToBooleanObject(ok)
+	case *object.Array:
+		return containsEqual(right.Elements, left)
+	case *object.Tuple:
+		return containsEqual(right.Elements, left)
+	case *object.String:
+		str, ok := left.(*object.String)
+		if !ok {
+			return newError("type mismatch: %s in %s", left.Type(), right.Type())
+		}
+		return boolToBooleanObject(strings.Contains(right.Value, str.Value))
+	default:
+		return newError("unknown operator: %s in %s", left.Type(), right.Type())
+	}
+}
+
+// containsEqual returns TRUE if any of elements is equal to value, or the error
+// raised by comparing them.
+func containsEqual(elements []object.Object, value object.Object) object.Object {
+	for _, element := range elements {
+		equal, err := object.Equal(element, value)
+		if err != nil {
+			return err
+		}
+		if equal {
+			return TRUE
+		}
+	}
+	return FALSE
+}
+
+// sequenceElements returns the elements of an array, tuple or set.
+func sequenceElements(obj object.Object) ([]object.Object, bool) {
+	switch obj := obj.(type) {
+	case *object.Array:
+		return obj.Elements, true
+	case *object.Tuple:
+		return obj.Elements, true
+	case *object.Set:
+		return obj.OrderedElements(), true
+	default:
+		return nil, false
+	}
+}
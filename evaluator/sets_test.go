@@ -0,0 +1,104 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestSets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"#{1, 2, 2, 3}", expectedSet{1, 2, 3}},
+		{`#{"a", [1, 2], (3, 4), true}`, expectedSet{"a", []interface{}{1, 2}, expectedTuple{3, 4}, true}},
+		{"#{}", expectedSet{}},
+		{"set()", expectedSet{}},
+		{"set([3, 1, 3])", expectedSet{3, 1}},
+		{"set((1, 1))", expectedSet{1}},
+		{"len(#{1, 2, 1})", 2},
+		{"#{1, 2} | #{2, 3}", expectedSet{1, 2, 3}},
+		{"#{1, 2, 3} & #{3, 2, 4}", expectedSet{2, 3}},
+		{"#{1, 2, 3} - #{2}", expectedSet{1, 3}},
+		{"#{1, 2} == #{2, 1}", true},
+		{"#{1, 2} != #{1}", true},
+		{"[2 in #{1, 2}, 3 in #{1, 2}, [1] in #{[1]}, {} in #{1}]", []interface{}{true, false, true, false}},
+		{"let f = fn() { for (x in #{3, 4}) { return x } }; f()", 3},
+		{"#{{}}", expectedError("object of type HASH cannot be used as a set element")},
+		{"set([[{}]])", expectedError("object of type ARRAY cannot be used as a set element")},
+		{"#{1} + #{2}", expectedError("unknown operator: SET + SET")},
+		{"#{1} | [2]", expectedError("type mismatch: SET | ARRAY")},
+		{"set(1)", expectedError("argument to `set` not supported, got integer")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestTuples(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"(1, 2 + 3)", expectedTuple{1, 5}},
+		{"(1,)", expectedTuple{1}},
+		{"()", expectedTuple{}},
+		{"(1, 2, 3)[-1]", 3},
+		{"(1, 2, 3)[1:]", expectedTuple{2, 3}},
+		{"len((1, 2))", 2},
+		{"tuple([1, 2])", expectedTuple{1, 2}},
+		{"(1, [2]) == (1, [2])", true},
+		{"(1, 2) == [1, 2]", false},
+		{`{(1, 2): "a"}[(1, 2)]`, "a"},
+		{`let point = (1, 2); {point: "a"}[(1, 2)]`, "a"},
+		{"(1, 2) in [(1, 2)]", true},
+		{"2 in (1, 2)", true},
+		{"let [x, ...rest] = (1, 2, 3); rest", expectedTuple{2, 3}},
+		{"match ((1, 2)) { [1, y] => y }", 2},
+		{"let f = fn() { for (i, x in (5, 6)) { if (i == 1) { return x } } }; f()", 6},
+		{"(1, 2)[2]", expectedError("index 2 exceeds bounds of tuple of length 2")},
+		{"push((1, 2), 3)", expectedError("argument to `push` not supported, got TUPLE")},
+		{`{(1, {}): 1}`, expectedError("object of type TUPLE cannot be used as a hash key")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestInOperator(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"key" in "monkey"`, true},
+		{`"dog" in "monkey"`, false},
+		{`"a" in {"a": 1}`, true},
+		{`1 in {"a": 1}`, false},
+		{`[1] in [[1], [2]]`, true},
+		{`3 in [1, 2]`, false},
+		{`1 in "monkey"`, "type mismatch: integer in STRING"},
+		{`1 in 2`, "unknown operator: integer in integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			switch expected := tt.expected.(type) {
+			case bool:
+				testBooleanObject(t, evaluated, expected)
+			case string:
+				if err, ok := evaluated.(*object.Error); !ok || err.Message != expected {
+					t.Errorf("wrong result. want=%q, got=%q", expected, evaluated.Inspect())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+func evalSliceExpression(se *ast.SliceExpression, env *object.Environment) (object.Object, bool) {
+	left, skipped := evalChainTarget(se.Left, se.Optional, env)
+	if skipped || isError(left) {
+		return left, skipped
+	}
+
+	return evalSlice(left, se, env), false
+}
+
+// evalSlice slices left by the bounds of se, which are evaluated in env.
+func evalSlice(left object.Object, se *ast.SliceExpression, env *object.Environment) object.Object {
+	bounds := make([]*int64, 2)
+	for i, exp := range []ast.Expression{se.Low, se.High} {
+		if exp == nil {
+			continue
+		}
+
+		bound := Eval(exp, env)
+		if isError(bound) {
+			return bound
+		}
+
+		integer, ok := bound.(*object.Integer)
+		if !ok {
+			return newError("slice bound must be an integer, got %s", bound.Type())
+		}
+		bounds[i] = &integer.Value
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		low, high := sliceBounds(bounds[0], bounds[1], len(left.Elements))
+		elements := make([]object.Object, high-low)
+		copy(elements, left.Elements[low:high])
+		return &object.Array{Elements: elements}
+	case *object.Tuple:
+		low, high := sliceBounds(bounds[0], bounds[1], len(left.Elements))
+		elements := make([]object.Object, high-low)
+		copy(elements, left.Elements[low:high])
+		return &object.Tuple{Elements: elements}
+	case *object.String:
+		runes := []rune(left.Value)
+		low, high := sliceBounds(bounds[0], bounds[1], len(runes))
+		return &object.String{Value: string(runes[low:high])}
+	default:
+		return newError("slice operator not supported: %s", left.Type())
+	}
+}
+
+func evalTupleIndexExpression(tuple, index object.Object) object.Object {
+	idx := index.(*object.Integer).Value
+	elements := tuple.(*object.Tuple).Elements
+
+	i, ok := resolveIndex(idx, len(elements))
+	if !ok {
+		return newError("index %d exceeds bounds of tuple of length %d", idx, len(elements))
+	}
+
+	return elements[i]
+}
+
+// resolveIndex converts an index that may count back from the end of a sequence
+// of the given length (-1 being the last element) into an offset from the start,
+// reporting whether it's in bounds.
+func resolveIndex(index int64, length int) (int, bool) {
+	if index < 0 {
+		index += int64(length)
+	}
+	if index < 0 || index >= int64(length) {
+		return 0, false
+	}
+	return int(index), true
+}
+
+// sliceBounds resolves the optional bounds of a slice of a sequence of the given
+// length. Negative bounds count back from the end, and bounds outside of the
+// sequence are clamped to it, so slicing never fails.
+func sliceBounds(low, high *int64, length int) (int, int) {
+	clamp := func(bound *int64, fallback int) int {
+		if bound == nil {
+			return fallback
+		}
+
+		value := *bound
+		if value < 0 {
+			value += int64(length)
+		}
+		if value < 0 {
+			return 0
+		}
+		if value > int64(length) {
+			return length
+		}
+		return int(value)
+	}
+
+	start, end := clamp(low, 0), clamp(high, length)
+	if start > end {
+		start = end
+	}
+	return start, end
+}
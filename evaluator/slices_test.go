@@ -0,0 +1,34 @@
+package evaluator
+
+import "testing"
+
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"[1, 2, 3, 4][1:3]", []interface{}{2, 3}},
+		{"[1, 2, 3, 4][:2]", []interface{}{1, 2}},
+		{"[1, 2, 3, 4][-2:]", []interface{}{3, 4}},
+		{"[1, 2, 3, 4][:-1]", []interface{}{1, 2, 3}},
+		{"[1, 2, 3, 4][:]", []interface{}{1, 2, 3, 4}},
+		{"[1, 2, 3, 4][3:1]", []interface{}{}},
+		{"[1, 2, 3, 4][-10:10]", []interface{}{1, 2, 3, 4}},
+		{"let a = [1, 2, 3]; let b = a[:]; push(b, 4); a", []interface{}{1, 2, 3}},
+		{`"monkey"[1:4]`, "onk"},
+		{`"héllo"[1:3]`, "él"},
+		{`"héllo"[-3:]`, "llo"},
+		{`"héllo"[-1]`, "o"},
+		{`"héllo"[-6]`, expectedError("index -6 exceeds bounds of string of length 5")},
+		{"let n = null; n?[1:2]", nil},
+		{`[1, 2]["a":]`, expectedError("slice bound must be an integer, got STRING")},
+		{"1[1:2]", expectedError("slice operator not supported: integer")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package evaluator
+
+import (
+	"math"
+	"monkey-interpreter/object"
+	"time"
+)
+
+// STD_PREFIX is the prefix of import paths referring to standard library modules.
+const STD_PREFIX = "std/"
+
+// stdModules holds the builtins backing each standard library module, keyed by
+// module name. Every builtin is exported by its module alongside the names
+// exported by the module's Monkey source in the std package.
+var stdModules = map[string]map[string]*object.Builtin{
+	"math": {
+		"abs": {
+			Fn: func(args ...object.Object) object.Object {
+				if err := checkArgs("abs", args, object.INTEGER_OBJ); err != nil {
+					return err
+				}
+
+				value := args[0].(*object.Integer).Value
+				if value < 0 {
+					value = -value
+				}
+				return intToIntegerObject(value)
+			},
+		},
+		"min": {
+			Fn: func(args ...object.Object) object.Object {
+				return extremum("min", args, func(a, b int64) bool { return a < b })
+			},
+		},
+		"max": {
+			Fn: func(args ...object.Object) object.Object {
+				return extremum("max", args, func(a, b int64) bool { return a > b })
+			},
+		},
+		"pow": {
+			Fn: func(args ...object.Object) object.Object {
+				if err := checkArgs("pow", args, object.INTEGER_OBJ, object.INTEGER_OBJ); err != nil {
+					return err
+				}
+
+				base, exponent := args[0].(*object.Integer).Value, args[1].(*object.Integer).Value
+				if exponent < 0 {
+					return newError("`pow` does not support negative exponents, got %d", exponent)
+				}
+
+				// Exponentiation by squaring, so that large exponents take
+				// logarithmic time. Results overflow like other integer
+				// arithmetic.
+				result := int64(1)
+				for ; exponent > 0; exponent >>= 1 {
+					if exponent&1 == 1 {
+						result *= base
+					}
+					base *= base
+				}
+				return intToIntegerObject(result)
+			},
+		},
+		"sqrt": {
+			Fn: func(args ...object.Object) object.Object {
+				if err := checkArgs("sqrt", args, object.INTEGER_OBJ); err != nil {
+					return err
+				}
+
+				value := args[0].(*object.Integer).Value
+				if value < 0 {
+					return newError("`sqrt` does not support negative numbers, got %d", value)
+				}
+				return intToIntegerObject(int64(math.Sqrt(float64(value))))
+			},
+		},
+		"mod": {
+			Fn: func(args ...object.Object) object.Object {
+				if err := checkArgs("mod", args, object.INTEGER_OBJ, object.INTEGER_OBJ); err != nil {
+					return err
+				}
+
+				a, b := args[0].(*object.Integer).Value, args[1].(*object.Integer).Value
+				if b == 0 {
+					return newError("division by zero")
+				}
+				return intToIntegerObject(a % b)
+			},
+		},
+	},
+	"strings": stringBuiltins,
+	"list": {
+		"concat": {
+			Fn: func(args ...object.Object) object.Object {
+				elements := make([]object.Object, 0)
+
+				for _, arg := range args {
+					array, ok := arg.(*object.Array)
+					if !ok {
+						return newError("argument to `concat` not supported, got %s", arg.Type())
+					}
+					elements = append(elements, array.Elements...)
+				}
+
+				return &object.Array{Elements: elements}
+			},
+		},
+	},
+	"hash": hashBuiltins,
+	"time": {
+		"now": {
+			Fn: func(args ...object.Object) object.Object {
+				if err := checkArgs("now", args); err != nil {
+					return err
+				}
+				return intToIntegerObject(time.Now().UnixNano() / int64(time.Millisecond))
+			},
+		},
+		"since": {
+			Fn: func(args ...object.Object) object.Object {
+				if err := checkArgs("since", args, object.INTEGER_OBJ); err != nil {
+					return err
+				}
+
+				now := time.Now().UnixNano() / int64(time.Millisecond)
+				return intToIntegerObject(now - args[0].(*object.Integer).Value)
+			},
+		},
+		"sleep": {
+			Fn: func(args ...object.Object) object.Object {
+				if err := checkArgs("sleep", args, object.INTEGER_OBJ); err != nil {
+					return err
+				}
+
+				time.Sleep(time.Duration(args[0].(*object.Integer).Value) * time.Millisecond)
+				return NULL
+			},
+		},
+	},
+}
+
+// checkArgs returns an error if args don't have the types wanted by the builtin
+// called name, using the same messages as the global builtins.
+func checkArgs(name string, args []object.Object, want ...object.ObjectType) *object.Error {
+	if len(args) != len(want) {
+		return newError("wrong number of arguments. got=%d, want=%d", len(args), len(want))
+	}
+
+	for i, arg := range args {
+		if arg.Type() != want[i] {
+			return newError("argument to `%s` not supported, got %s", name, arg.Type())
+		}
+	}
+
+	return nil
+}
+
+func extremum(name string, args []object.Object, better func(a, b int64) bool) object.Object {
+	if len(args) == 0 {
+		return newError("wrong number of arguments. got=0, wanted at least 1")
+	}
+
+	var result int64
+	for i, arg := range args {
+		integer, ok := arg.(*object.Integer)
+		if !ok {
+			return newError("argument to `%s` not supported, got %s", name, arg.Type())
+		}
+
+		if i == 0 || better(integer.Value, result) {
+			result = integer.Value
+		}
+	}
+
+	return intToIntegerObject(result)
+}
@@ -0,0 +1,47 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestStdModules(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`import { abs, min, max } from "std/math"; [abs(-3), min(4, 2, 8), max(4, 2, 8)]`, []interface{}{3, 2, 8}},
+		{`import "std/math" as m; [m.pow(2, 10), m.sqrt(17), m.mod(7, 3)]`, []interface{}{1024, 4, 1}},
+		{`import "std/math" as m; [m.pow(3, 0), m.pow(-3, 3), m.pow(7, 13), m.pow(1, 4611686018427387904)]`, []interface{}{1, -27, 96889010407, 1}},
+		{`import { clamp, sign, isEven, isOdd } from "std/math"; [clamp(12, 0, 10), sign(-4), isEven(4), isOdd(4)]`, []interface{}{10, -1, true, false}},
+		{`import "std/math" as m; m.mod(1, 0)`, expectedError("division by zero")},
+		{`import "std/math" as m; m.abs("1")`, expectedError("argument to `abs` not supported, got STRING")},
+		{`import "std/strings" as s; s.join(s.split("a,b,c", ","), "-")`, "a-b-c"},
+		{`import "std/strings" as s; [s.upper("ab"), s.lower("AB"), s.trim("  x ")]`, []interface{}{"AB", "ab", "x"}},
+		{`import "std/strings" as s; [s.contains("monkey", "key"), s.startsWith("monkey", "mon"), s.endsWith("monkey", "mon")]`, []interface{}{true, true, false}},
+		{`import "std/strings" as s; [s.replace("a-b-c", "-", "+"), s.repeat("ab", 3), s.length("héllo")]`, []interface{}{"a+b+c", "ababab", 5}},
+		{`import { words, surround } from "std/strings"; [words("  the quick  fox "), surround("x", "(", ")")]`, []interface{}{[]interface{}{"the", "quick", "fox"}, "(x)"}},
+		{`import "std/strings" as s; s.repeat("ab")`, expectedError("wrong number of arguments. got=1, want=2")},
+		{`import "std/list" as l; l.map([1, 2, 3], fn(x) { x * 2 })`, []interface{}{2, 4, 6}},
+		{`import "std/list" as l; l.filter([1, 2, 3, 4], fn(x) { x > 2 })`, []interface{}{3, 4}},
+		{`import "std/list" as l; [l.sum([1, 2, 3]), l.contains([1, 2], 2), l.isEmpty([])]`, []interface{}{6, true, true}},
+		{`import "std/list" as l; [l.reverse([1, 2, 3]), l.concat([1], [2, 3])]`, []interface{}{[]interface{}{3, 2, 1}, []interface{}{1, 2, 3}}},
+		{`import "std/hash" as h; let x = {"a": 1}; [h.keys(x), h.values(x), h.has(x, "a"), h.entries(x)]`, []interface{}{[]interface{}{"a"}, []interface{}{1}, true, []interface{}{[]interface{}{"a", 1}}}},
+		{`import "std/hash" as h; [h.get({"a": 1}, "a", 0), h.get({}, "a", 0), h.isEmpty({})]`, []interface{}{1, 0, true}},
+		{`import "std/time" as time; time.since(time.now()) > -1`, true},
+		{`import "std/time" as time; time.MINUTE / time.SECOND`, 60},
+		{`import "std/time" as time; time.measure(fn() { time.sleep(1) }) > 0`, true},
+		{`import "std/missing" as m;`, expectedError("cannot find module \"std/missing\"")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			Modules = NewModuleLoader()
+			evaluated := Eval(testParseProgram(t, tt.input), object.NewEnvironment())
+			if evaluated == nil {
+				t.Fatalf("no result for %q", tt.input)
+			}
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
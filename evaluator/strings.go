@@ -0,0 +1,183 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"strings"
+	"unicode/utf8"
+)
+
+// stringBuiltins are available globally and exported by std/strings. Lengths,
+// widths and indexes are counted in runes rather than bytes.
+var stringBuiltins = map[string]*object.Builtin{
+	"split": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("split", args, object.STRING_OBJ, object.STRING_OBJ); err != nil {
+				return err
+			}
+
+			s, separator := args[0].(*object.String).Value, args[1].(*object.String).Value
+			return stringsToArray(strings.Split(s, separator))
+		},
+	},
+	"join": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("join", args, object.ARRAY_OBJ, object.STRING_OBJ); err != nil {
+				return err
+			}
+
+			elements := args[0].(*object.Array).Elements
+			parts := make([]string, len(elements))
+			for i, element := range elements {
+				str, ok := element.(*object.String)
+				if !ok {
+					return newError("argument to `join` not supported, got array containing %s", element.Type())
+				}
+				parts[i] = str.Value
+			}
+
+			return &object.String{Value: strings.Join(parts, args[1].(*object.String).Value)}
+		},
+	},
+	"trim": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("trim", args, object.STRING_OBJ); err != nil {
+				return err
+			}
+			return &object.String{Value: strings.TrimSpace(args[0].(*object.String).Value)}
+		},
+	},
+	"upper": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("upper", args, object.STRING_OBJ); err != nil {
+				return err
+			}
+			return &object.String{Value: strings.ToUpper(args[0].(*object.String).Value)}
+		},
+	},
+	"lower": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("lower", args, object.STRING_OBJ); err != nil {
+				return err
+			}
+			return &object.String{Value: strings.ToLower(args[0].(*object.String).Value)}
+		},
+	},
+	"contains": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("contains", args, object.STRING_OBJ, object.STRING_OBJ); err != nil {
+				return err
+			}
+
+			s, substr := args[0].(*object.String).Value, args[1].(*object.String).Value
+			return boolToBooleanObject(strings.Contains(s, substr))
+		},
+	},
+	"replace": {
+		Fn: func(args ...object.Object) object.Object {
+			err := checkArgs("replace", args, object.STRING_OBJ, object.STRING_OBJ, object.STRING_OBJ)
+			if err != nil {
+				return err
+			}
+
+			s, old, new := args[0].(*object.String).Value, args[1].(*object.String).Value, args[2].(*object.String).Value
+			return &object.String{Value: strings.ReplaceAll(s, old, new)}
+		},
+	},
+	"startsWith": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("startsWith", args, object.STRING_OBJ, object.STRING_OBJ); err != nil {
+				return err
+			}
+
+			s, prefix := args[0].(*object.String).Value, args[1].(*object.String).Value
+			return boolToBooleanObject(strings.HasPrefix(s, prefix))
+		},
+	},
+	"endsWith": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("endsWith", args, object.STRING_OBJ, object.STRING_OBJ); err != nil {
+				return err
+			}
+
+			s, suffix := args[0].(*object.String).Value, args[1].(*object.String).Value
+			return boolToBooleanObject(strings.HasSuffix(s, suffix))
+		},
+	},
+	// padLeft(s, width, pad) prepends copies of pad (a single character, or a
+	// space if omitted) to s until it is width runes long.
+	"padLeft": {
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) == 2 {
+				args = append(args, &object.String{Value: " "})
+			}
+
+			err := checkArgs("padLeft", args, object.STRING_OBJ, object.INTEGER_OBJ, object.STRING_OBJ)
+			if err != nil {
+				return err
+			}
+
+			s, width, pad := args[0].(*object.String).Value, args[1].(*object.Integer).Value, args[2].(*object.String).Value
+			if utf8.RuneCountInString(pad) != 1 {
+				return newError("`padLeft` needs a single character to pad with, got %q", pad)
+			}
+
+			if missing := int(width) - utf8.RuneCountInString(s); missing > 0 {
+				s = strings.Repeat(pad, missing) + s
+			}
+			return &object.String{Value: s}
+		},
+	},
+	"repeat": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("repeat", args, object.STRING_OBJ, object.INTEGER_OBJ); err != nil {
+				return err
+			}
+
+			count := args[1].(*object.Integer).Value
+			if count < 0 {
+				return newError("`repeat` does not support negative counts, got %d", count)
+			}
+			return &object.String{Value: strings.Repeat(args[0].(*object.String).Value, int(count))}
+		},
+	},
+	"chars": {
+		Fn: func(args ...object.Object) object.Object {
+			if err := checkArgs("chars", args, object.STRING_OBJ); err != nil {
+				return err
+			}
+
+			runes := []rune(args[0].(*object.String).Value)
+			elements := make([]object.Object, len(runes))
+			for i, r := range runes {
+				elements[i] = &object.String{Value: string(r)}
+			}
+			return &object.Array{Elements: elements}
+		},
+	},
+}
+
+func init() {
+	for name, builtin := range stringBuiltins {
+		builtins[name] = builtin
+	}
+}
+
+func evalStringIndexExpression(str, index object.Object) object.Object {
+	idx := index.(*object.Integer).Value
+	runes := []rune(str.(*object.String).Value)
+
+	i, ok := resolveIndex(idx, len(runes))
+	if !ok {
+		return newError("index %d exceeds bounds of string of length %d", idx, len(runes))
+	}
+
+	return &object.String{Value: string(runes[i])}
+}
+
+func stringsToArray(strs []string) *object.Array {
+	elements := make([]object.Object, len(strs))
+	for i, s := range strs {
+		elements[i] = &object.String{Value: s}
+	}
+	return &object.Array{Elements: elements}
+}
@@ -0,0 +1,80 @@
+package evaluator
+
+import "testing"
+
+func TestStringBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`split("a,b,,c", ",")`, []interface{}{"a", "b", "", "c"}},
+		{`split("héllo", "")`, []interface{}{"h", "é", "l", "l", "o"}},
+		{`join(["a", "b", "c"], ", ")`, "a, b, c"},
+		{`join([], "-")`, ""},
+		{`join(["a", 1], "-")`, expectedError("argument to `join` not supported, got array containing integer")},
+		{`trim("  monkey  ")`, "monkey"},
+		{`upper("héllo")`, "HÉLLO"},
+		{`lower("ÉCOLE")`, "école"},
+		{`[contains("monkey", "key"), contains("monkey", "dog")]`, []interface{}{true, false}},
+		{`replace("a-b-c", "-", "")`, "abc"},
+		{`[startsWith("monkey", "mon"), startsWith("monkey", "key"), endsWith("monkey", "key")]`, []interface{}{true, false, true}},
+		{`padLeft("42", 5, "0")`, "00042"},
+		{`padLeft("été", 5)`, "  été"},
+		{`padLeft("monkey", 3)`, "monkey"},
+		{`padLeft("1", 3, "ab")`, expectedError("`padLeft` needs a single character to pad with, got \"ab\"")},
+		{`repeat("é", 3)`, "ééé"},
+		{`repeat("a", -1)`, expectedError("`repeat` does not support negative counts, got -1")},
+		{`chars("añb")`, []interface{}{"a", "ñ", "b"}},
+		{`chars(1)`, expectedError("argument to `chars` not supported, got integer")},
+		{`upper("a", "b")`, expectedError("wrong number of arguments. got=2, want=1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestStringIndexing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"monkey"[0]`, "m"},
+		{`"héllo"[1]`, "é"},
+		{`"héllo"[4]`, "o"},
+		{`"日本語"[2]`, "語"},
+		{`"héllo"[5]`, expectedError("index 5 exceeds bounds of string of length 5")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestStringComparison(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`"a" < "b"`, true},
+		{`"b" < "a"`, false},
+		{`"abc" > "abd"`, false},
+		{`"é" > "z"`, true},
+		{`"monkey" == "monkey"`, true},
+		{`"monkey" == "Monkey"`, false},
+		{`"monkey" != "Monkey"`, true},
+		{`"" < "a"`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			testBooleanObject(t, testEval(tt.input), tt.expected)
+		})
+	}
+}
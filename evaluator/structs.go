@@ -0,0 +1,101 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/object"
+)
+
+func evalStructStatement(node *ast.StructStatement, env *object.Environment) object.Object {
+	fields := make([]string, len(node.Fields))
+	for i, field := range node.Fields {
+		fields[i] = field.Value
+	}
+
+	if err := define(env, node.Name.Value, &object.StructType{Name: node.Name.Value, Fields: fields}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// newStruct constructs an instance of definition from a value for each of its
+// fields, in the order they were declared.
+func newStruct(definition *object.StructType, args []object.Object) object.Object {
+	if len(args) != len(definition.Fields) {
+		return newError("wrong number of arguments. got=%d, want=%d", len(args), len(definition.Fields))
+	}
+
+	fields := make(map[string]object.Object, len(args))
+	for i, field := range definition.Fields {
+		fields[field] = args[i]
+	}
+
+	return &object.Struct{Definition: definition, Fields: fields}
+}
+
+func evalStructField(instance *object.Struct, name string) object.Object {
+	value, ok := instance.Fields[name]
+	if !ok {
+		return newError("%s has no field %s", instance.Definition.Name, name)
+	}
+	return value
+}
+
+// evalAssignExpression stores the value of node.Value in the field referred to by
+// node.Target, which the parser guarantees is a member or index expression. Only
+// the fields of structs and instances can be assigned to (and the elements of
+// instances with a __setindex__ method). Arrays and hashes can't be modified,
+// since they can be used as hash keys.
+func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
+	var container, key object.Object
+	switch target := node.Target.(type) {
+	case *ast.MemberExpression:
+		container = Eval(target.Object, env)
+		key = &object.String{Value: target.Property.Value}
+	case *ast.IndexExpression:
+		container = Eval(target.Left, env)
+		if isError(container) {
+			return container
+		}
+		key = Eval(target.Index, env)
+	}
+	if isError(container) {
+		return container
+	}
+	if isError(key) {
+		return key
+	}
+
+	value := Eval(node.Value, env)
+	if isError(value) {
+		return value
+	}
+
+	switch container := container.(type) {
+	case *object.Struct:
+		name, ok := key.(*object.String)
+		if !ok {
+			return newError("index operator not supported: %s", container.Type())
+		}
+		if !container.Definition.HasField(name.Value) {
+			return newError("%s has no field %s", container.Definition.Name, name.Value)
+		}
+		container.Fields[name.Value] = value
+	case *object.Instance:
+		// Indexed assignment goes through the __setindex__ method of the class.
+		if _, ok := node.Target.(*ast.IndexExpression); ok {
+			result, ok := callSpecialMethod(container, "__setindex__", key, value)
+			if !ok {
+				return newError("index operator not supported: %s", container.Type())
+			}
+			if isError(result) {
+				return result
+			}
+			return value
+		}
+		container.SetField(key.(*object.String).Value, value)
+	default:
+		return newError("cannot assign to elements of %s", container.Type())
+	}
+
+	return value
+}
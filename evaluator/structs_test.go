@@ -0,0 +1,61 @@
+package evaluator
+
+import (
+	"monkey-interpreter/object"
+	"testing"
+)
+
+func TestStructs(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"struct Point { x, y }; Point", inspected{object.STRUCT_TYPE_OBJ, "struct Point { x, y }"}},
+		{"struct Point { x, y }; Point(1, 2)", expectedStruct{"Point", map[string]interface{}{"x": 1, "y": 2}}},
+		{"struct Point { x, y }; let p = Point(1, [2]); p.y", []interface{}{2}},
+		{"struct Point { x, y }; let p = Point(1, 2); p.x = 5; p", expectedStruct{"Point", map[string]interface{}{"x": 5, "y": 2}}},
+		{"struct Point { x, y }; let p = Point(1, 2); p.x = p.y = 3; [p.x, p.y]", []interface{}{3, 3}},
+		{"struct Point { x, y }; Point(1, 2) == Point(1, 2)", true},
+		{"struct Point { x, y }; Point(1, 2) != Point(1, 3)", true},
+		{"struct A { x }; struct B { x }; A(1) == B(1)", false},
+		{"struct Point { x, y }; map([[1, 2], [3, 4]], fn([x, y]) { Point(x, y) })", []interface{}{expectedStruct{"Point", map[string]interface{}{"x": 1, "y": 2}}, expectedStruct{"Point", map[string]interface{}{"x": 3, "y": 4}}}},
+		{"struct Point { x, y }; map([1], Point)", expectedError("wrong number of arguments. got=1, want=2")},
+		{"struct Point { x, y }; Point(1)", expectedError("wrong number of arguments. got=1, want=2")},
+		{"struct Point { x, y }; Point(1, 2).z", expectedError("Point has no field z")},
+		{"struct Point { x, y }; let p = Point(1, 2); p.z = 3", expectedError("Point has no field z")},
+		{"struct Point { x, y }; Point(1, 2) + Point(1, 2)", expectedError("unknown operator: STRUCT + STRUCT")},
+		{"struct Point { x, y }; {Point(1, 2): 1}", expectedError("object of type STRUCT cannot be used as a hash key")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestAssignment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`struct P { x }; let p = P(1); p["x"] = 2; p.x`, 2},
+		{"struct P { x }; let ps = [P(1)]; ps[0].x = 2; ps", []interface{}{expectedStruct{"P", map[string]interface{}{"x": 2}}}},
+		{`struct P { x }; let h = {"p": P(1)}; h["p"].x = 2; h`, expectedHash{"p": expectedStruct{"P", map[string]interface{}{"x": 2}}}},
+		{`let h = {"a": 1}; h.b = 2`, expectedError("cannot assign to elements of HASH")},
+		{`let h = {"a": 1}; h["a"] = 3`, expectedError("cannot assign to elements of HASH")},
+		{"let a = [1, 2, 3]; a[0] = 0", expectedError("cannot assign to elements of ARRAY")},
+		{"let h = {[1]: true}; let k = [1]; k[0] = 2; h[[1]]", expectedError("cannot assign to elements of ARRAY")},
+		{"let t = (1, 2); t[0] = 2", expectedError("cannot assign to elements of TUPLE")},
+		{`let s = "ab"; s[0] = "c"`, expectedError("cannot assign to elements of STRING")},
+		{"let h = {}; h.x = y", expectedError("identifier not found: y")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package evaluator
+
+import "monkey-interpreter/ast"
+
+// markTailCalls records the calls in a function body whose result is returned by
+// the function, either as the value of a return statement or as the last
+// expression of the body (or of a branch that is). Calls inside try expressions
+// aren't recorded, since their errors must be caught and their finally blocks
+// run after the call.
+func (r *resolver) markTailCalls(block *ast.BlockStatement, tail bool) {
+	for i, statement := range block.Statements {
+		switch statement := statement.(type) {
+		case *ast.ReturnStatement:
+			r.markTailExpression(statement.Value, true)
+		case *ast.ExpressionStatement:
+			r.markTailExpression(statement.Expression, tail && i == len(block.Statements)-1)
+		}
+	}
+}
+
+func (r *resolver) markTailExpression(expression ast.Expression, tail bool) {
+	switch expression := expression.(type) {
+	case *ast.CallExpression:
+		if tail {
+			r.tailCalls[expression]++
+		}
+	case *ast.IfExpression:
+		r.markTailCalls(expression.Consequence, tail)
+		if expression.ElseIf != nil {
+			r.markTailExpression(expression.ElseIf, tail)
+		}
+		if expression.Alternative != nil {
+			r.markTailCalls(expression.Alternative, tail)
+		}
+	case *ast.ConditionalExpression:
+		r.markTailExpression(expression.Consequence, tail)
+		r.markTailExpression(expression.Alternative, tail)
+	case *ast.MatchExpression:
+		for _, arm := range expression.Arms {
+			if body, ok := arm.Body.(*ast.BlockStatement); ok {
+				r.markTailCalls(body, tail)
+			} else if body, ok := arm.Body.(ast.Expression); ok {
+				r.markTailExpression(body, tail)
+			}
+		}
+	case *ast.ForExpression:
+		// Loops evaluate to null, but can return from within their body.
+		r.markTailCalls(expression.Body, false)
+	}
+}
+
+// markTails sets the Tail flag of each call resolved, which is only set if the
+// call is in tail position everywhere it appears. Macros can splice the same
+// node into more than one place, and a call that's made where it appears can't
+// evaluate to a TailCall.
+func (r *resolver) markTails() {
+	for call, count := range r.calls {
+		call.Tail = r.tailCalls[call] == count
+	}
+}
@@ -0,0 +1,134 @@
+package evaluator
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/lexer"
+	"monkey-interpreter/object"
+	"monkey-interpreter/parser"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestMarkTailCalls(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn() { a(b()) }", "a"},
+		{"fn() { a(); b() }", "b"},
+		{"fn() { return a(b()); c() }", "a c"},
+		{"fn() { 1 + a() }", ""},
+		{"fn() { let x = a(); x }", ""},
+		{"fn() { if (x) { a() } else if (y) { b() } else { c() } }", "a b c"},
+		{"fn() { if (x) { a() }; b() }", "b"},
+		{"fn() { if (x) { return a() }; b() }", "a b"},
+		{"fn() { x ? a() : b() }", "a b"},
+		{"fn() { match (x) { 1 => a(), _ => { b(); c() } } }", "a c"},
+		{"fn() { for (x in xs) { a(); return b() } }", "b"},
+		{"fn() { try { return a() } catch (e) { b() } }", ""},
+		{"fn*() { a() }", ""},
+		{"a()", ""},
+		{"class A { f() { self.g() } }", "(self.g)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			program := parser.New(lexer.New(tt.input)).ParseProgram()
+			Resolve(program, object.NewEnvironment())
+
+			calls := make([]string, 0)
+			ast.Modify(program, func(node ast.Node) ast.Node {
+				if call, ok := node.(*ast.CallExpression); ok && call.Tail {
+					calls = append(calls, call.Function.String())
+				}
+				return node
+			})
+
+			if got := strings.Join(calls, " "); got != tt.expected {
+				t.Errorf("wrong tail calls. want=%q, got=%q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTailCalls(t *testing.T) {
+	// Without tail calls, recursing this deep needs far more stack than this.
+	defer debug.SetMaxStack(debug.SetMaxStack(16 << 20))
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let sum = fn(n, acc) { if (n == 0) { acc } else { sum(n - 1, acc + n) } }; sum(100000, 0)", 5000050000},
+		{"let count = fn(n) { if (n == 0) { return \"done\" }; return count(n - 1) }; count(100000)", "done"},
+		{"let even = fn(n) { n == 0 ? true : odd(n - 1) }; let odd = fn(n) { n == 0 ? false : even(n - 1) }; [even(100000), odd(7)]", []interface{}{true, true}},
+		{"let f = fn(n, acc) { match (n) { 0 => acc, _ => f(n - 1, acc + 1) } }; f(100000, 0)", 100000},
+		{"class C { count(n) { if (n == 0) { \"done\" } else { self.count(n - 1) } } }; C().count(100000)", "done"},
+		{"let last = fn(xs) { for (x in xs) { if (len(xs) == 1) { return x } ; return last(xs[1:]) } }; last(collect(range(1000)))", 999},
+		{"let f = fn(n) { if (n == 0) { 0 } else { 1 + f(n - 1) } }; f(100)", 100},
+		{"let g = fn() { throw \"boom\" }; let f = fn() { try { return g() } catch (e) { \"caught\" } }; f()", "caught"},
+		{"let g = fn() { 1 }; let f = fn() { try { return g() } finally { throw \"finally\" } }; f()", expectedError("finally")},
+		{"let id = fn(x) { x }; let gen = fn*() { yield 1; id(2) }; collect(gen())", []interface{}{1}},
+		{"let f = fn(a, b) { a }; let g = fn() { f(1) }; g()", expectedError("wrong number of arguments. got=1, want=2")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			evaluated := testEval(tt.input)
+			testObject(t, evaluated, tt.expected)
+		})
+	}
+}
+
+func TestTailCallErrorStack(t *testing.T) {
+	evaluated := testEval(`
+		let inner = fn() { throw "boom" };
+		let loop = fn(n) { if (n == 0) { inner() } else { loop(n - 1) } };
+		let outer = fn() { loop(5) };
+		try { outer() } catch (e) { e["stack"] }
+	`)
+
+	expected := "[inner,loop,outer]"
+	if evaluated.Inspect() != expected {
+		t.Errorf("wrong stack. want=%s, got=%s", expected, evaluated.Inspect())
+	}
+
+	// Functions are only listed once however many times they call each other.
+	evaluated = testEval(`
+		let even = fn(n) { if (n == 0) { throw "boom" }; odd(n - 1) };
+		let odd = fn(n) { even(n - 1) };
+		try { even(100000) } catch (e) { e["stack"] }
+	`)
+
+	expected = "[even,odd]"
+	if evaluated.Inspect() != expected {
+		t.Errorf("wrong stack. want=%s, got=%s", expected, evaluated.Inspect())
+	}
+}
+
+// A call that's only in tail position in some of the places it appears must be
+// made where it appears.
+func TestSharedTailCalls(t *testing.T) {
+	program := testParseProgram(t, "let g = fn() { 2 }; let f = fn() { g() + fn() { 0 }() }; f()")
+
+	// Splice the call to g into the inner function, where it's in tail position.
+	body := program.Statements[1].(*ast.LetStatement).Value.(*ast.Function).Body
+	sum := body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+	inner := sum.Right.(*ast.CallExpression).Function.(*ast.Function)
+	inner.Body.Statements[0].(*ast.ExpressionStatement).Expression = sum.Left
+
+	if errs := Resolve(program, object.NewEnvironment()); len(errs) != 0 {
+		t.Fatalf("unexpected errors resolving program: %v", errs)
+	}
+	if sum.Left.(*ast.CallExpression).Tail {
+		t.Errorf("shared call marked as a tail call")
+	}
+	testIntegerObject(t, Eval(program, object.NewEnvironment()), 4)
+
+	// A TailCall that isn't made by applyFunction is made by the program.
+	program = testParseProgram(t, "let f = fn() { 1 }; f(); f()")
+	program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.CallExpression).Tail = true
+	program.Statements[2].(*ast.ExpressionStatement).Expression.(*ast.CallExpression).Tail = true
+	testIntegerObject(t, Eval(program, object.NewEnvironment()), 1)
+}
@@ -1,6 +1,6 @@
 module monkey-interpreter
 
-go 1.14
+go 1.16
 
 replace github.com/dcrodman/monkey-interpreter => /Users/dcrodman/Development/monkey-interpreter
 
@@ -51,6 +51,9 @@ func (l *Lexer) NextToken() token.Token {
 		if l.peekNextRune() == '=' {
 			l.moveToNextPosition()
 			literal, tokenType = token.EQ, token.EQ
+		} else if l.peekNextRune() == '>' {
+			l.moveToNextPosition()
+			literal, tokenType = token.ARROW, token.ARROW
 		} else {
 			tokenType = token.ASSIGN
 		}
@@ -61,6 +64,37 @@ func (l *Lexer) NextToken() token.Token {
 		} else {
 			tokenType = token.BANG
 		}
+	case '?':
+		switch l.peekNextRune() {
+		case '?':
+			l.moveToNextPosition()
+			literal, tokenType = token.NULLISH, token.NULLISH
+		case '.':
+			// `c ?.5 : 1` is a conditional, not an optional chain.
+			if unicode.IsDigit(l.readRune(l.currentPosition + 2)) {
+				tokenType = token.QUESTION
+			} else {
+				l.moveToNextPosition()
+				literal, tokenType = token.OPT_DOT, token.OPT_DOT
+			}
+		case '[':
+			if l.startsConditionalArray() {
+				tokenType = token.QUESTION
+			} else {
+				l.moveToNextPosition()
+				literal, tokenType = token.OPT_LBRACKET, token.OPT_LBRACKET
+			}
+		default:
+			tokenType = token.QUESTION
+		}
+	case '.':
+		if l.peekNextRune() == '.' && l.readRune(l.currentPosition+2) == '.' {
+			l.moveToNextPosition()
+			l.moveToNextPosition()
+			literal, tokenType = token.ELLIPSIS, token.ELLIPSIS
+		} else {
+			tokenType = token.DOT
+		}
 	case '+':
 		tokenType = token.PLUS
 	case '-':
@@ -69,6 +103,17 @@ func (l *Lexer) NextToken() token.Token {
 		tokenType = token.SLASH
 	case '*':
 		tokenType = token.ASTERISK
+	case '|':
+		tokenType = token.PIPE
+	case '&':
+		tokenType = token.AMP
+	case '#':
+		if l.peekNextRune() == '{' {
+			l.moveToNextPosition()
+			literal, tokenType = token.SET_BRACE, token.SET_BRACE
+		} else {
+			tokenType = token.ILLEGAL
+		}
 	case '{':
 		tokenType = token.LBRACE
 	case '}':
@@ -107,6 +152,29 @@ func (l *Lexer) NextToken() token.Token {
 	return token.Token{Type: tokenType, Value: literal}
 }
 
+// startsConditionalArray reports whether the `[` after the `?` at the current
+// position starts an array literal in a conditional expression (`c ?[1] : [2]`)
+// rather than an optional index (`a?[1]`), which is when the matching `]` is
+// followed by a colon.
+func (l *Lexer) startsConditionalArray() bool {
+	scanner := *l
+	scanner.moveToNextPosition()
+
+	depth := 0
+	for {
+		switch scanner.NextToken().Type {
+		case token.LBRACKET, token.OPT_LBRACKET:
+			depth++
+		case token.RBRACKET:
+			if depth--; depth == 0 {
+				return scanner.NextToken().Type == token.COLON
+			}
+		case token.EOF:
+			return false
+		}
+	}
+}
+
 func (l *Lexer) getNextRune() rune {
 	nextRune := l.peekCurrentRune()
 
@@ -147,11 +215,18 @@ func (l *Lexer) moveToNextPosition() {
 func (l *Lexer) readLiteral() (string, token.TokenType) {
 	var literal string
 
-	for r := l.peekCurrentRune(); unicode.IsDigit(r) || unicode.IsLetter(r); r = l.peekCurrentRune() {
+	for r := l.peekCurrentRune(); unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_'; r = l.peekCurrentRune() {
 		literal += string(r)
 		l.moveToNextPosition()
 	}
 
+	// Characters that can't start any token are skipped over so that lexing can continue.
+	if literal == "" {
+		literal = string(l.peekCurrentRune())
+		l.moveToNextPosition()
+		return literal, token.ILLEGAL
+	}
+
 	if unicode.IsDigit(rune(literal[0])) {
 		if !token.IsValidInteger(literal) {
 			return literal, token.ILLEGAL
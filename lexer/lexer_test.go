@@ -31,6 +31,14 @@ func TestLexer_NextToken(t *testing.T) {
 
 	[1, 2];
 	{"foo": "bar"};
+
+	null ?? a?.b?[c]?.(d);
+	a ? b : c;
+	a ?[b] : [c];
+	a ?.5 : 1;
+	match (my_var) { [_, ...rest] => rest }
+	#{a} | b & c;
+	a.b;
 	`
 	lexer := New(code)
 
@@ -135,6 +143,76 @@ func TestLexer_NextToken(t *testing.T) {
 		{token.COLON, ":"},
 		{token.STRING, "bar"},
 		{token.RBRACE, "}"},
+		{token.SEMICOLON, ";"},
+
+		{token.NULL, "null"},
+		{token.NULLISH, "??"},
+		{token.IDENTIFIER, "a"},
+		{token.OPT_DOT, "?."},
+		{token.IDENTIFIER, "b"},
+		{token.OPT_LBRACKET, "?["},
+		{token.IDENTIFIER, "c"},
+		{token.RBRACKET, "]"},
+		{token.OPT_DOT, "?."},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "d"},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, ";"},
+
+		{token.IDENTIFIER, "a"},
+		{token.QUESTION, "?"},
+		{token.IDENTIFIER, "b"},
+		{token.COLON, ":"},
+		{token.IDENTIFIER, "c"},
+		{token.SEMICOLON, ";"},
+
+		{token.IDENTIFIER, "a"},
+		{token.QUESTION, "?"},
+		{token.LBRACKET, "["},
+		{token.IDENTIFIER, "b"},
+		{token.RBRACKET, "]"},
+		{token.COLON, ":"},
+		{token.LBRACKET, "["},
+		{token.IDENTIFIER, "c"},
+		{token.RBRACKET, "]"},
+		{token.SEMICOLON, ";"},
+
+		{token.IDENTIFIER, "a"},
+		{token.QUESTION, "?"},
+		{token.DOT, "."},
+		{token.INT, "5"},
+		{token.COLON, ":"},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+
+		{token.MATCH, "match"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "my_var"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.LBRACKET, "["},
+		{token.IDENTIFIER, "_"},
+		{token.COMMA, ","},
+		{token.ELLIPSIS, "..."},
+		{token.IDENTIFIER, "rest"},
+		{token.RBRACKET, "]"},
+		{token.ARROW, "=>"},
+		{token.IDENTIFIER, "rest"},
+		{token.RBRACE, "}"},
+
+		{token.SET_BRACE, "#{"},
+		{token.IDENTIFIER, "a"},
+		{token.RBRACE, "}"},
+		{token.PIPE, "|"},
+		{token.IDENTIFIER, "b"},
+		{token.AMP, "&"},
+		{token.IDENTIFIER, "c"},
+		{token.SEMICOLON, ";"},
+
+		{token.IDENTIFIER, "a"},
+		{token.DOT, "."},
+		{token.IDENTIFIER, "b"},
+		{token.SEMICOLON, ";"},
 
 		{token.EOF, "EOF"},
 	}
@@ -0,0 +1,123 @@
+package object
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Channel passes values between functions running concurrently. Unlike Go
+// channels, sending on a closed channel is an error rather than a panic, and
+// receiving from a closed (and drained) channel returns false.
+type Channel struct {
+	Capacity int
+
+	values    chan Object
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func NewChannel(capacity int) *Channel {
+	return &Channel{Capacity: capacity, values: make(chan Object, capacity), closed: make(chan struct{})}
+}
+
+// Send blocks until value is received (or buffered), reporting false if the
+// channel is closed.
+func (c *Channel) Send(value Object) bool {
+	if c.IsClosed() {
+		return false
+	}
+
+	select {
+	case c.values <- value:
+		return true
+	case <-c.closed:
+		return false
+	}
+}
+
+// Receive blocks until a value is sent, reporting false if the channel is closed
+// and there are no buffered values left.
+func (c *Channel) Receive() (Object, bool) {
+	select {
+	case value := <-c.values:
+		return value, true
+	case <-c.closed:
+		return c.Drain()
+	}
+}
+
+// Close closes the channel, waking any blocked senders and receivers. Closing a
+// channel more than once has no effect.
+func (c *Channel) Close() {
+	c.closeOnce.Do(func() { close(c.closed) })
+}
+
+func (c *Channel) IsClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ReceiveChans returns the Go channels that Receive waits on, for use by
+// select statements waiting on several channels at once. A value arriving on
+// closed should be followed by a call to Drain.
+func (c *Channel) ReceiveChans() (values <-chan Object, closed <-chan struct{}) {
+	return c.values, c.closed
+}
+
+// Drain returns a value left in the buffer of a closed channel, if there is one.
+func (c *Channel) Drain() (Object, bool) {
+	select {
+	case value := <-c.values:
+		return value, true
+	default:
+		return nil, false
+	}
+}
+
+func (c *Channel) Type() ObjectType { return CHANNEL_OBJ }
+func (c *Channel) Inspect() string {
+	if c.IsClosed() {
+		return fmt.Sprintf("channel(%d, closed)", c.Capacity)
+	}
+	return fmt.Sprintf("channel(%d)", c.Capacity)
+}
+
+// Task is the result of a function started with spawn, which is available once
+// the function returns.
+type Task struct {
+	Name string
+
+	done   chan struct{}
+	result Object
+}
+
+func NewTask(name string) *Task {
+	return &Task{Name: name, done: make(chan struct{})}
+}
+
+// Finish sets the result of the task, waking anything waiting for it. It must
+// only be called once.
+func (t *Task) Finish(result Object) {
+	t.result = result
+	close(t.done)
+}
+
+// Wait blocks until the task finishes and returns its result.
+func (t *Task) Wait() Object {
+	<-t.done
+	return t.result
+}
+
+func (t *Task) Type() ObjectType { return TASK_OBJ }
+func (t *Task) Inspect() string {
+	select {
+	case <-t.done:
+		return "task " + t.Name + " (done)"
+	default:
+		return "task " + t.Name
+	}
+}
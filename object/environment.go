@@ -1,21 +1,112 @@
 package object
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Environment binds names to values. Environments are safe for concurrent use,
+// so functions started with spawn can share the environments they close over;
+// the values bound in them (such as arrays and hashes) are not synchronized.
 type Environment struct {
+	mu      sync.RWMutex
 	symbols map[string]Object
 
+	// locals are the names given slots in a frame created by NewFrame, and slots
+	// hold their values (or nil until they're set).
+	locals []string
+	slots  []Object
+
+	// shared is set once the environment may be used by more than one goroutine,
+	// after which it's locked when it's accessed. Frames aren't shared until
+	// Share is called on them; other environments always are.
+	shared int32
+	// frozen is set once the symbols can no longer change, after which they're
+	// read without locking.
+	frozen int32
+
 	outer *Environment
+
+	// modulePath is the path of the source file whose top-level scope this
+	// environment is, if it was created by NewModuleEnvironment.
+	modulePath string
+
+	// yield is set on the environment of a running generator's body.
+	yield YieldFunc
 }
 
+// YieldFunc passes a value yielded by a generator to its consumer, blocking until
+// the consumer asks for the next value. It reports false if the generator has
+// been stopped and should not continue.
+type YieldFunc func(Object) bool
+
 func NewEnvironment() *Environment {
 	return NewEnclosingEnvironment(nil)
 }
 
+// NewModuleEnvironment creates the top-level environment for the module at path,
+// which is used to resolve imports made from within the module.
+func NewModuleEnvironment(path string) *Environment {
+	env := NewEnvironment()
+	env.modulePath = path
+	return env
+}
+
 func NewEnclosingEnvironment(enclosing *Environment) *Environment {
-	return &Environment{symbols: make(map[string]Object), outer: enclosing}
+	return &Environment{symbols: make(map[string]Object), outer: enclosing, shared: 1}
+}
+
+// NewFrame creates an environment for a scope whose names were resolved ahead of
+// time, storing the value of each of locals in a slot rather than by name. Other
+// names can still be set and are stored by name.
+func NewFrame(enclosing *Environment, locals []string) *Environment {
+	return &Environment{outer: enclosing, locals: locals, slots: make([]Object, len(locals))}
+}
+
+// Share marks the environment and those enclosing it as possibly used by more
+// than one goroutine. Frames are accessed without locking until then, so Share
+// must be called before anything that can reach a frame from another goroutine
+// (such as a function closing over it) is created.
+func (e *Environment) Share() {
+	for env := e; env != nil && atomic.LoadInt32(&env.shared) == 0; env = env.outer {
+		atomic.StoreInt32(&env.shared, 1)
+	}
+}
+
+// locking reports whether accesses to the environment need to be locked.
+func (e *Environment) locking() bool {
+	return atomic.LoadInt32(&e.shared) == 1 && !e.Frozen()
+}
+
+// Freeze makes the environment immutable so that it can be shared by any number
+// of goroutines without contention, such as a prelude of definitions that each
+// script is evaluated in a NewEnclosingEnvironment of. Names defined by those
+// scripts are bound in their own environments and don't affect the prelude.
+//
+// Setting a name in a frozen environment panics, so the evaluator reports an
+// error for definitions made in one instead. Values bound in the prelude are
+// shared by every script, so mutable ones shouldn't be modified.
+func (e *Environment) Freeze() {
+	e.mu.Lock()
+	atomic.StoreInt32(&e.frozen, 1)
+	e.mu.Unlock()
+}
+
+// Frozen reports whether Freeze has been called on the environment.
+func (e *Environment) Frozen() bool {
+	return atomic.LoadInt32(&e.frozen) == 1
 }
 
 func (e *Environment) Get(identifier string) (Object, bool) {
-	val, ok := e.symbols[identifier]
+	var val Object
+	var ok bool
+	if e.locking() {
+		e.mu.RLock()
+		val, ok = e.lookup(identifier)
+		e.mu.RUnlock()
+	} else {
+		val, ok = e.lookup(identifier)
+	}
 
 	if !ok && e.outer != nil {
 		val, ok = e.outer.Get(identifier)
@@ -23,7 +114,109 @@ func (e *Environment) Get(identifier string) (Object, bool) {
 	return val, ok
 }
 
+// GetAt returns the value in slot of the frame depth environments out from e,
+// reporting false if it hasn't been set.
+func (e *Environment) GetAt(depth, slot int) (Object, bool) {
+	frame := e.frame(depth)
+	if frame == nil || slot >= len(frame.slots) {
+		return nil, false
+	}
+
+	var val Object
+	if frame.locking() {
+		frame.mu.RLock()
+		val = frame.slots[slot]
+		frame.mu.RUnlock()
+	} else {
+		val = frame.slots[slot]
+	}
+	return val, val != nil
+}
+
+// SetAt sets the value in slot of the frame depth environments out from e, which
+// must have been created by NewFrame with a local for the slot.
+func (e *Environment) SetAt(depth, slot int, val Object) Object {
+	frame := e.frame(depth)
+	if frame.locking() {
+		frame.mu.Lock()
+		frame.slots[slot] = val
+		frame.mu.Unlock()
+	} else {
+		frame.slots[slot] = val
+	}
+	return val
+}
+
+func (e *Environment) frame(depth int) *Environment {
+	frame := e
+	for ; depth > 0 && frame != nil; depth-- {
+		frame = frame.outer
+	}
+	return frame
+}
+
+func (e *Environment) lookup(identifier string) (Object, bool) {
+	if val, ok := e.symbols[identifier]; ok {
+		return val, true
+	}
+
+	if slot := e.slot(identifier); slot != -1 && e.slots[slot] != nil {
+		return e.slots[slot], true
+	}
+	return nil, false
+}
+
+func (e *Environment) slot(identifier string) int {
+	for i, local := range e.locals {
+		if local == identifier {
+			return i
+		}
+	}
+	return -1
+}
+
 func (e *Environment) Set(identifier string, val Object) Object {
+	if e.Frozen() {
+		panic("object: cannot set " + identifier + " in a frozen environment")
+	}
+
+	if e.locking() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+	}
+
+	if slot := e.slot(identifier); slot != -1 {
+		e.slots[slot] = val
+		return val
+	}
+
+	if e.symbols == nil {
+		e.symbols = make(map[string]Object)
+	}
 	e.symbols[identifier] = val
 	return val
 }
+
+// SetYield makes yield the function used by yield expressions evaluated in the
+// environment and the environments it encloses.
+func (e *Environment) SetYield(yield YieldFunc) {
+	e.yield = yield
+}
+
+// Yield returns the function used to yield values from the innermost generator
+// whose body is being evaluated in this environment.
+func (e *Environment) Yield() (YieldFunc, bool) {
+	if e.yield == nil && e.outer != nil {
+		return e.outer.Yield()
+	}
+	return e.yield, e.yield != nil
+}
+
+// ModulePath returns the path of the module that the environment belongs to, or
+// an empty string if it wasn't created for a module (such as in the REPL).
+func (e *Environment) ModulePath() string {
+	if e.modulePath == "" && e.outer != nil {
+		return e.outer.ModulePath()
+	}
+	return e.modulePath
+}
@@ -0,0 +1,126 @@
+package object
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestFrozenEnvironment(t *testing.T) {
+	prelude := NewEnvironment()
+	prelude.Set("x", &Integer{Value: 1})
+	prelude.Freeze()
+
+	env := NewEnclosingEnvironment(prelude)
+	env.Set("x", &Integer{Value: 2})
+
+	if val, _ := env.Get("x"); val.Inspect() != "2" {
+		t.Errorf("expected enclosed binding to shadow the prelude, got %s", val.Inspect())
+	}
+	if val, _ := prelude.Get("x"); val.Inspect() != "1" {
+		t.Errorf("expected prelude binding to be unchanged, got %s", val.Inspect())
+	}
+	if env.Frozen() || !prelude.Frozen() {
+		t.Errorf("expected only the prelude to be frozen")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected setting a name in a frozen environment to panic")
+		}
+	}()
+	prelude.Set("y", &Integer{Value: 3})
+}
+
+// Environments are shared between goroutines, so this should be run with -race.
+func TestConcurrentEnvironmentAccess(t *testing.T) {
+	prelude := NewEnvironment()
+	prelude.Set("x", &Integer{Value: 1})
+	prelude.Freeze()
+
+	shared := NewEnclosingEnvironment(prelude)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			env := NewEnclosingEnvironment(shared)
+			for j := 0; j < 100; j++ {
+				name := fmt.Sprintf("v%d_%d", i, j)
+				shared.Set(name, &Integer{Value: int64(j)})
+				env.Set(name, &Integer{Value: int64(i)})
+
+				if _, ok := env.Get("x"); !ok {
+					t.Errorf("expected x to be found in the prelude")
+				}
+				if val, _ := env.Get(name); val.Inspect() != fmt.Sprint(i) {
+					t.Errorf("expected %s=%d, got %s", name, i, val.Inspect())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestFrames(t *testing.T) {
+	outer := NewFrame(NewEnvironment(), []string{"a", "b"})
+	outer.Set("b", &Integer{Value: 2})
+	outer.Set("c", &Integer{Value: 3})
+
+	inner := NewFrame(outer, []string{"a"})
+	inner.SetAt(0, 0, &Integer{Value: 1})
+
+	if val, ok := inner.GetAt(0, 0); !ok || val.Inspect() != "1" {
+		t.Errorf("expected a=1 in slot 0 of the inner frame, got %v", val)
+	}
+	if val, ok := inner.GetAt(1, 1); !ok || val.Inspect() != "2" {
+		t.Errorf("expected b=2 in slot 1 of the outer frame, got %v", val)
+	}
+	if _, ok := inner.GetAt(1, 0); ok {
+		t.Errorf("expected unset slot to not be found")
+	}
+	if _, ok := inner.GetAt(5, 0); ok {
+		t.Errorf("expected frame beyond the outermost environment to not be found")
+	}
+
+	for name, expected := range map[string]string{"a": "1", "b": "2", "c": "3"} {
+		if val, ok := inner.Get(name); !ok || val.Inspect() != expected {
+			t.Errorf("expected %s=%s by name, got %v", name, expected, val)
+		}
+	}
+
+	inner.SetAt(1, 0, &Integer{Value: 4})
+	if val, ok := outer.Get("a"); !ok || val.Inspect() != "4" {
+		t.Errorf("expected a=4 by name after setting slot 0 of the outer frame, got %v", val)
+	}
+}
+
+// Frames are only locked once they're shared, so this should be run with -race.
+func TestSharedFrames(t *testing.T) {
+	outer := NewFrame(NewEnvironment(), []string{"n"})
+	outer.SetAt(0, 0, &Integer{Value: 0})
+	inner := NewFrame(outer, []string{"m"})
+	inner.Share()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				inner.SetAt(1, 0, &Integer{Value: int64(j)})
+				inner.Set("m", &Integer{Value: int64(i)})
+				if _, ok := inner.GetAt(1, 0); !ok {
+					t.Errorf("expected n to be found in the outer frame")
+				}
+				if _, ok := outer.Get("n"); !ok {
+					t.Errorf("expected n to be found by name")
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
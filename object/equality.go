@@ -0,0 +1,219 @@
+package object
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// Equal reports whether a and b have the same value. Arrays and tuples are equal
+// if their elements are pairwise equal, hashes are equal if they hold equal values
+// under the same keys and sets are equal if they hold the same elements (both
+// regardless of insertion order). Structs are equal if they're instances of the
+// same declaration with equal fields, and enum values are equal if they're the
+// same variant with equal values. Instances are compared with EqualInstances, so
+// classes can define their own equality; the first error raised by doing so stops
+// the comparison and is returned. Other objects without a value of their own, such
+// as functions, are only equal to themselves. Values that contain themselves are
+// compared without recursing forever.
+func Equal(a, b Object) (bool, *Error) {
+	c := &comparison{comparing: make(map[[2]Object]bool)}
+	return c.equal(a, b), c.err
+}
+
+// comparison holds the state of a call to Equal. comparing holds the pairs of
+// containers currently being compared further up the stack: meeting one again
+// means a cycle was followed, and the pair is assumed to be equal since any
+// difference will be found along another path.
+type comparison struct {
+	comparing map[[2]Object]bool
+	err       *Error
+}
+
+func (c *comparison) equal(a, b Object) bool {
+	if a == b {
+		return true
+	}
+
+	switch a := a.(type) {
+	case *Integer:
+		other, ok := b.(*Integer)
+		return ok && a.Value == other.Value
+	case *String:
+		other, ok := b.(*String)
+		return ok && a.Value == other.Value
+	case *Boolean:
+		other, ok := b.(*Boolean)
+		return ok && a.Value == other.Value
+	case *Null:
+		_, ok := b.(*Null)
+		return ok
+	case *Array:
+		other, ok := b.(*Array)
+		return ok && c.elementsEqual(a, other, a.Elements, other.Elements)
+	case *Tuple:
+		other, ok := b.(*Tuple)
+		return ok && c.elementsEqual(a, other, a.Elements, other.Elements)
+	case *EnumValue:
+		other, ok := b.(*EnumValue)
+		return ok && a.Variant == other.Variant && c.elementsEqual(a, other, a.Values, other.Values)
+	case *Set:
+		other, ok := b.(*Set)
+		if !ok || len(a.Elements) != len(other.Elements) {
+			return false
+		}
+
+		// Set elements are hashable, so they can't contain cycles and equal
+		// elements have equal keys.
+		for key := range a.Elements {
+			if _, ok := other.Elements[key]; !ok {
+				return false
+			}
+		}
+		return true
+	case *Hash:
+		other, ok := b.(*Hash)
+		if !ok || len(a.Pairs) != len(other.Pairs) {
+			return false
+		}
+
+		pair := [2]Object{a, other}
+		if c.comparing[pair] {
+			return true
+		}
+		c.comparing[pair] = true
+		defer delete(c.comparing, pair)
+
+		for key, p := range a.Pairs {
+			otherPair, ok := other.Pairs[key]
+			if !ok || !c.equal(p.Value, otherPair.Value) {
+				return false
+			}
+		}
+		return true
+	case *Instance:
+		equal, err := EqualInstances(a, b)
+		if err != nil {
+			c.err = err
+		}
+		return equal
+	case *Struct:
+		other, ok := b.(*Struct)
+		if !ok || a.Definition != other.Definition {
+			return false
+		}
+
+		pair := [2]Object{a, other}
+		if c.comparing[pair] {
+			return true
+		}
+		c.comparing[pair] = true
+		defer delete(c.comparing, pair)
+
+		for _, field := range a.Definition.Fields {
+			if !c.equal(a.Fields[field], other.Fields[field]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *comparison) elementsEqual(a, b Object, aElements, bElements []Object) bool {
+	if len(aElements) != len(bElements) {
+		return false
+	}
+
+	pair := [2]Object{a, b}
+	if c.comparing[pair] {
+		return true
+	}
+	c.comparing[pair] = true
+	defer delete(c.comparing, pair)
+
+	for i, element := range aElements {
+		if !c.equal(element, bElements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHashable reports whether obj can be used as a hash key. Arrays, tuples and
+// enum values are hashable if all of their elements are.
+func IsHashable(obj Object) bool {
+	return isHashable(obj, make(map[Object]bool))
+}
+
+func isHashable(obj Object, visiting map[Object]bool) bool {
+	var elements []Object
+	switch obj := obj.(type) {
+	case *Array:
+		elements = obj.Elements
+	case *Tuple:
+		elements = obj.Elements
+	case *EnumValue:
+		elements = obj.Values
+	default:
+		_, ok := obj.(Hashable)
+		return ok
+	}
+
+	// A sequence containing itself has no finite hash key.
+	if visiting[obj] {
+		return false
+	}
+	visiting[obj] = true
+	defer delete(visiting, obj)
+
+	for _, element := range elements {
+		if !isHashable(element, visiting) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashKeyOf returns the hash key of obj, reporting false if it isn't hashable.
+func HashKeyOf(obj Object) (HashKey, bool) {
+	if !IsHashable(obj) {
+		return HashKey{}, false
+	}
+	return obj.(Hashable).HashKey(), true
+}
+
+// HashKey combines the hash keys of the elements of an array, so equal arrays have
+// equal keys. It must only be called on arrays for which IsHashable is true.
+func (a *Array) HashKey() HashKey {
+	return HashKey{Type: a.Type(), Value: combineHashKeys("", a.Elements)}
+}
+
+// HashKey combines the hash keys of the elements of a tuple. It must only be called
+// on tuples for which IsHashable is true.
+func (t *Tuple) HashKey() HashKey {
+	return HashKey{Type: t.Type(), Value: combineHashKeys("", t.Elements)}
+}
+
+// HashKey combines the name of the value's variant with the hash keys of its
+// values. It must only be called on enum values for which IsHashable is true.
+func (e *EnumValue) HashKey() HashKey {
+	name := e.Variant.Enum.Name + "." + e.Variant.Name
+	return HashKey{Type: e.Type(), Value: combineHashKeys(name, e.Values)}
+}
+
+// combineHashKeys hashes prefix followed by the hash keys of elements.
+func combineHashKeys(prefix string, elements []Object) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(prefix))
+
+	buf := make([]byte, 8)
+	for _, element := range elements {
+		key := element.(Hashable).HashKey()
+		h.Write([]byte(key.Type))
+		binary.LittleEndian.PutUint64(buf, key.Value)
+		h.Write(buf)
+	}
+
+	return h.Sum64()
+}
@@ -0,0 +1,87 @@
+package object
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	one, two := &Integer{Value: 1}, &Integer{Value: 2}
+	str := func(s string) *String { return &String{Value: s} }
+	array := func(elements ...Object) *Array { return &Array{Elements: elements} }
+	hash := func(pairs ...Object) *Hash {
+		h := NewHash()
+		for i := 0; i < len(pairs); i += 2 {
+			h.Set(pairs[i].(Hashable).HashKey(), HashPair{Key: pairs[i], Value: pairs[i+1]})
+		}
+		return h
+	}
+	fn := &Builtin{}
+	point := &StructType{Name: "Point", Fields: []string{"x"}}
+	instance := func(definition *StructType, x Object) *Struct {
+		return &Struct{Definition: definition, Fields: map[string]Object{"x": x}}
+	}
+
+	tests := []struct {
+		a, b     Object
+		expected bool
+	}{
+		{one, &Integer{Value: 1}, true},
+		{one, two, false},
+		{str("a"), str("a"), true},
+		{str("1"), one, false},
+		{array(one, str("a")), array(&Integer{Value: 1}, str("a")), true},
+		{array(one), array(one, two), false},
+		{array(array(one)), array(array(one)), true},
+		{array(array(one)), array(array(two)), false},
+		{hash(str("a"), one, str("b"), two), hash(str("b"), two, str("a"), one), true},
+		{hash(str("a"), one), hash(str("a"), two), false},
+		{hash(str("a"), one), hash(str("b"), one), false},
+		{hash(str("a"), array(one)), hash(str("a"), array(one)), true},
+		{array(), hash(), false},
+		{instance(point, array(one)), instance(point, array(one)), true},
+		{instance(point, one), instance(point, two), false},
+		{instance(point, one), instance(&StructType{Name: "Point", Fields: []string{"x"}}, one), false},
+		{fn, fn, true},
+		{fn, &Builtin{}, false},
+	}
+
+	for _, tt := range tests {
+		if got, _ := Equal(tt.a, tt.b); got != tt.expected {
+			t.Errorf("Equal(%s, %s) = %t, want %t", tt.a.Inspect(), tt.b.Inspect(), got, tt.expected)
+		}
+	}
+}
+
+func TestEqualCycles(t *testing.T) {
+	a := &Array{Elements: []Object{&Integer{Value: 1}}}
+	a.Elements = append(a.Elements, a)
+	b := &Array{Elements: []Object{&Integer{Value: 1}}}
+	b.Elements = append(b.Elements, b)
+	c := &Array{Elements: []Object{&Integer{Value: 2}}}
+	c.Elements = append(c.Elements, c)
+
+	if equal, _ := Equal(a, b); !equal {
+		t.Errorf("equal cyclic arrays are not equal")
+	}
+	if equal, _ := Equal(a, c); equal {
+		t.Errorf("different cyclic arrays are equal")
+	}
+	if IsHashable(a) {
+		t.Errorf("cyclic array is hashable")
+	}
+}
+
+func TestArrayHashKey(t *testing.T) {
+	one := &Array{Elements: []Object{&Integer{Value: 1}, &String{Value: "a"}}}
+	same := &Array{Elements: []Object{&Integer{Value: 1}, &String{Value: "a"}}}
+	diff := &Array{Elements: []Object{&String{Value: "a"}, &Integer{Value: 1}}}
+
+	if one.HashKey() != same.HashKey() {
+		t.Errorf("arrays with same content have different hash keys")
+	}
+	if one.HashKey() == diff.HashKey() {
+		t.Errorf("arrays with different content have same hash keys")
+	}
+
+	if _, ok := HashKeyOf(&Array{Elements: []Object{NewHash()}}); ok {
+		t.Errorf("array containing a hash is hashable")
+	}
+}
@@ -0,0 +1,54 @@
+package object
+
+// Iterator is a lazily produced sequence of values, such as the integers in a
+// range or the values yielded by a generator. Values are produced one at a time
+// by Next, so sequences can be arbitrarily long (or infinite) without being held
+// in memory.
+type Iterator struct {
+	// Name describes where the values come from, such as "range" or the name
+	// of a generator function.
+	Name string
+
+	next func() (Object, bool)
+	stop func()
+	done bool
+}
+
+// NewIterator creates an iterator producing the values returned by next until it
+// reports false. stop, if not nil, is called if the iterator is stopped before
+// next is exhausted.
+func NewIterator(name string, next func() (Object, bool), stop func()) *Iterator {
+	return &Iterator{Name: name, next: next, stop: stop}
+}
+
+// Next returns the next value in the sequence, reporting false once there are no
+// more. An error ends the sequence after it's returned.
+func (it *Iterator) Next() (Object, bool) {
+	if it.done {
+		return nil, false
+	}
+
+	value, ok := it.next()
+	if !ok {
+		it.done = true
+	} else if _, isError := value.(*Error); isError {
+		it.Stop()
+	}
+	return value, ok
+}
+
+// Stop ends the sequence early, releasing whatever was needed to produce the
+// rest of it (such as the goroutine running a generator).
+func (it *Iterator) Stop() {
+	if it.done {
+		return
+	}
+
+	it.done = true
+	if it.stop != nil {
+		it.stop()
+	}
+}
+
+func (it *Iterator) Type() ObjectType { return ITERATOR_OBJ }
+func (it *Iterator) Inspect() string  { return "iterator " + it.Name }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"monkey-interpreter/ast"
+	"sort"
 	"strings"
 )
 
@@ -16,13 +17,37 @@ const (
 	FUNCTION_OBJ = "function"
 
 	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	TAIL_CALL_OBJ    = "TAIL_CALL"
 	ERROR_OBJ        = "ERROR_OBJ"
 
 	STRING_OBJ = "STRING"
 	ARRAY_OBJ  = "ARRAY"
 	HASH_OBJ   = "HASH"
+	SET_OBJ    = "SET"
+	TUPLE_OBJ  = "TUPLE"
 
-	BUILTIN_OBJ = "BUILTIN"
+	STRUCT_TYPE_OBJ = "STRUCT_TYPE"
+	STRUCT_OBJ      = "STRUCT"
+
+	ENUM_OBJ       = "ENUM"
+	VARIANT_OBJ    = "VARIANT"
+	ENUM_VALUE_OBJ = "ENUM_VALUE"
+
+	CLASS_OBJ    = "CLASS"
+	INSTANCE_OBJ = "INSTANCE"
+	SUPER_OBJ    = "SUPER"
+
+	ITERATOR_OBJ = "ITERATOR"
+	CHANNEL_OBJ  = "CHANNEL"
+	TASK_OBJ     = "TASK"
+
+	BUILTIN_OBJ      = "BUILTIN"
+	BOUND_METHOD_OBJ = "BOUND_METHOD"
+
+	QUOTE_OBJ = "QUOTE"
+	MACRO_OBJ = "MACRO"
+
+	MODULE_OBJ = "MODULE"
 )
 
 type Object interface {
@@ -110,8 +135,49 @@ type HashPair struct {
 	Value Object
 }
 
+// Hash maps hashable keys to values, remembering the order keys were inserted in.
+// Pairs can be read directly, but must only be modified through Set and Delete.
 type Hash struct {
 	Pairs map[HashKey]HashPair
+	keys  []HashKey
+}
+
+func NewHash() *Hash {
+	return &Hash{Pairs: make(map[HashKey]HashPair)}
+}
+
+// Set adds or replaces the pair stored under key. Replacing a pair doesn't change
+// its position in the hash.
+func (h *Hash) Set(key HashKey, pair HashPair) {
+	if _, ok := h.Pairs[key]; !ok {
+		h.keys = append(h.keys, key)
+	}
+	h.Pairs[key] = pair
+}
+
+// Delete removes the pair stored under key, reporting whether there was one.
+func (h *Hash) Delete(key HashKey) bool {
+	if _, ok := h.Pairs[key]; !ok {
+		return false
+	}
+
+	delete(h.Pairs, key)
+	for i, k := range h.keys {
+		if k == key {
+			h.keys = append(h.keys[:i:i], h.keys[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// OrderedPairs returns the pairs of the hash in insertion order.
+func (h *Hash) OrderedPairs() []HashPair {
+	pairs := make([]HashPair, len(h.keys))
+	for i, key := range h.keys {
+		pairs[i] = h.Pairs[key]
+	}
+	return pairs
 }
 
 func (h *Hash) Type() ObjectType { return HASH_OBJ }
@@ -119,7 +185,7 @@ func (h *Hash) Inspect() string {
 	var str strings.Builder
 
 	pairs := make([]string, 0)
-	for _, pair := range h.Pairs {
+	for _, pair := range h.OrderedPairs() {
 		pairs = append(pairs, fmt.Sprintf("%s:%s", pair.Key.Inspect(), pair.Value.Inspect()))
 	}
 
@@ -130,6 +196,278 @@ func (h *Hash) Inspect() string {
 	return str.String()
 }
 
+// Set holds distinct hashable values, remembering the order they were added in.
+// Elements can be read directly, but must only be modified through Add.
+type Set struct {
+	Elements map[HashKey]Object
+	keys     []HashKey
+}
+
+func NewSet() *Set {
+	return &Set{Elements: make(map[HashKey]Object)}
+}
+
+// Add adds value to the set under key, unless the set already holds it.
+func (s *Set) Add(key HashKey, value Object) {
+	if _, ok := s.Elements[key]; !ok {
+		s.keys = append(s.keys, key)
+		s.Elements[key] = value
+	}
+}
+
+// OrderedElements returns the elements of the set in insertion order.
+func (s *Set) OrderedElements() []Object {
+	elements := make([]Object, len(s.keys))
+	for i, key := range s.keys {
+		elements[i] = s.Elements[key]
+	}
+	return elements
+}
+
+func (s *Set) Type() ObjectType { return SET_OBJ }
+func (s *Set) Inspect() string {
+	var str strings.Builder
+
+	elements := make([]string, 0)
+	for _, element := range s.OrderedElements() {
+		elements = append(elements, element.Inspect())
+	}
+
+	str.WriteString("#{")
+	str.WriteString(strings.Join(elements, ", "))
+	str.WriteString("}")
+
+	return str.String()
+}
+
+// Tuple is an immutable sequence of values. Tuples of hashable values can be used
+// as hash keys and set elements.
+type Tuple struct {
+	Elements []Object
+}
+
+func (*Tuple) Type() ObjectType { return TUPLE_OBJ }
+func (t *Tuple) Inspect() string {
+	var str strings.Builder
+
+	elements := make([]string, 0)
+	for _, elem := range t.Elements {
+		elements = append(elements, elem.Inspect())
+	}
+
+	str.WriteString("(")
+	str.WriteString(strings.Join(elements, ", "))
+	if len(elements) == 1 {
+		str.WriteString(",")
+	}
+	str.WriteString(")")
+
+	return str.String()
+}
+
+// StructType is a struct declared by `struct Name { fields }`. Calling it with a
+// value for each field, in order, constructs a Struct.
+type StructType struct {
+	Name   string
+	Fields []string
+}
+
+func (s *StructType) Type() ObjectType { return STRUCT_TYPE_OBJ }
+func (s *StructType) Inspect() string {
+	if len(s.Fields) == 0 {
+		return fmt.Sprintf("struct %s {}", s.Name)
+	}
+	return fmt.Sprintf("struct %s { %s }", s.Name, strings.Join(s.Fields, ", "))
+}
+
+// HasField reports whether the struct declares a field called name.
+func (s *StructType) HasField(name string) bool {
+	for _, field := range s.Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Struct is an instance of a StructType, holding a value for each of its fields.
+// Fields can be updated but not added or removed.
+type Struct struct {
+	Definition *StructType
+	Fields     map[string]Object
+}
+
+func (s *Struct) Type() ObjectType { return STRUCT_OBJ }
+func (s *Struct) Inspect() string {
+	var str strings.Builder
+
+	fields := make([]string, 0)
+	for _, field := range s.Definition.Fields {
+		fields = append(fields, fmt.Sprintf("%s:%s", field, s.Fields[field].Inspect()))
+	}
+
+	str.WriteString(s.Definition.Name)
+	str.WriteString("{")
+	str.WriteString(strings.Join(fields, ", "))
+	str.WriteString("}")
+
+	return str.String()
+}
+
+// Enum is an enum declared by `enum Name { variants }`.
+type Enum struct {
+	Name     string
+	Variants []*Variant
+}
+
+func (e *Enum) Type() ObjectType { return ENUM_OBJ }
+func (e *Enum) Inspect() string {
+	variants := make([]string, len(e.Variants))
+	for i, variant := range e.Variants {
+		variants[i] = variant.Name
+		if variant.Fields != nil {
+			variants[i] += "(" + strings.Join(variant.Fields, ", ") + ")"
+		}
+	}
+
+	return fmt.Sprintf("enum %s { %s }", e.Name, strings.Join(variants, ", "))
+}
+
+// Variant returns the variant of the enum called name, if there is one.
+func (e *Enum) Variant(name string) (*Variant, bool) {
+	for _, variant := range e.Variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+	return nil, false
+}
+
+// Variant is one of the variants of an enum. Variants with fields are called with
+// a value for each field to construct an EnumValue; variants without fields (which
+// have nil Fields) are values themselves.
+type Variant struct {
+	Enum   *Enum
+	Name   string
+	Fields []string
+}
+
+func (v *Variant) Type() ObjectType { return VARIANT_OBJ }
+func (v *Variant) Inspect() string {
+	return fmt.Sprintf("variant %s.%s(%s)", v.Enum.Name, v.Name, strings.Join(v.Fields, ", "))
+}
+
+// EnumValue is a value of an enum, holding the values of its variant's fields.
+type EnumValue struct {
+	Variant *Variant
+	Values  []Object
+}
+
+func (e *EnumValue) Type() ObjectType { return ENUM_VALUE_OBJ }
+func (e *EnumValue) Inspect() string {
+	name := e.Variant.Enum.Name + "." + e.Variant.Name
+	if e.Variant.Fields == nil {
+		return name
+	}
+
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		values[i] = value.Inspect()
+	}
+
+	return name + "(" + strings.Join(values, ", ") + ")"
+}
+
+// Class is a class declared by `class Name { methods }`. Calling it constructs an
+// Instance, passing the arguments to its init method if it has one.
+type Class struct {
+	Name    string
+	Parent  *Class
+	Methods map[string]*Function
+}
+
+func (c *Class) Type() ObjectType { return CLASS_OBJ }
+func (c *Class) Inspect() string {
+	if c.Parent != nil {
+		return fmt.Sprintf("class %s extends %s", c.Name, c.Parent.Name)
+	}
+	return "class " + c.Name
+}
+
+// FindMethod looks up the method called name in the class and then in each of its
+// ancestors, returning the method and the class that defines it.
+func (c *Class) FindMethod(name string) (*Function, *Class, bool) {
+	for class := c; class != nil; class = class.Parent {
+		if method, ok := class.Methods[name]; ok {
+			return method, class, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Instance is an object constructed by calling a Class. Unlike structs, instances
+// gain fields when they're assigned to (usually by `self.field = value` in init).
+// Fields can be read directly, but must only be modified through SetField.
+type Instance struct {
+	Class  *Class
+	Fields map[string]Object
+	names  []string
+}
+
+func NewInstance(class *Class) *Instance {
+	return &Instance{Class: class, Fields: make(map[string]Object)}
+}
+
+// SetField sets the value of the named field, adding it if it doesn't exist yet.
+func (i *Instance) SetField(name string, value Object) {
+	if _, ok := i.Fields[name]; !ok {
+		i.names = append(i.names, name)
+	}
+	i.Fields[name] = value
+}
+
+// InspectInstance is called by Instance.Inspect so that the evaluator can format
+// instances using their class's __inspect__ method. It reports false to fall back
+// to the default format.
+var InspectInstance = func(*Instance) (string, bool) { return "", false }
+
+// EqualInstances is called by Equal so that the evaluator can compare instances
+// with other objects using their class's __eq__ method, returning any error it
+// raises. Instances of classes without one are only equal to themselves.
+var EqualInstances = func(*Instance, Object) (bool, *Error) { return false, nil }
+
+func (i *Instance) Type() ObjectType { return INSTANCE_OBJ }
+func (i *Instance) Inspect() string {
+	if inspected, ok := InspectInstance(i); ok {
+		return inspected
+	}
+
+	var str strings.Builder
+
+	fields := make([]string, 0)
+	for _, name := range i.names {
+		fields = append(fields, fmt.Sprintf("%s:%s", name, i.Fields[name].Inspect()))
+	}
+
+	str.WriteString(i.Class.Name)
+	str.WriteString("{")
+	str.WriteString(strings.Join(fields, ", "))
+	str.WriteString("}")
+
+	return str.String()
+}
+
+// Super is bound to `super` within the methods of a class that extends another.
+// Methods looked up on it are found starting from Class (the parent of the class
+// defining the method) and are bound to Instance.
+type Super struct {
+	Instance *Instance
+	Class    *Class
+}
+
+func (s *Super) Type() ObjectType { return SUPER_OBJ }
+func (s *Super) Inspect() string  { return "super " + s.Class.Name }
+
 type Return struct {
 	Value Object
 }
@@ -139,17 +477,44 @@ func (r *Return) Inspect() string {
 	return fmt.Sprintf("return %s", r.Value.Inspect())
 }
 
+// TailCall is the result of evaluating a call in tail position, which is made
+// once the function containing it has returned so that recursive calls don't use
+// up the Go stack.
+type TailCall struct {
+	Function  *Function
+	Arguments []Object
+}
+
+func (*TailCall) Type() ObjectType { return TAIL_CALL_OBJ }
+func (t *TailCall) Inspect() string {
+	return fmt.Sprintf("tail call %s", t.Function.Inspect())
+}
+
+// Error is raised by failed operations and by throw statements, and aborts
+// evaluation until it's caught by a try expression. Stack lists the names of the
+// functions the error propagated out of, innermost first.
 type Error struct {
 	Message string
+	Kind    string
+	// Value is the value passed to throw, if the error was thrown by a script.
+	Value Object
+	Stack []string
 }
 
 func (e *Error) Type() ObjectType { return ERROR_OBJ }
 func (e *Error) Inspect() string  { return "ERROR: " + e.Message }
 
 type Function struct {
-	Parameters []*ast.Identifier
+	// Name is the name the function was first bound to, if any.
+	Name       string
+	Parameters []ast.Pattern
 	Body       *ast.BlockStatement
 	Env        *Environment
+	// Generator is set for functions declared with `fn*`, which return an
+	// Iterator over the values their body yields when called.
+	Generator bool
+	// Locals are the names given slots in the frames the function is called in.
+	Locals []string
 }
 
 func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
@@ -161,7 +526,11 @@ func (f *Function) Inspect() string {
 		params = append(params, param.String())
 	}
 
-	str.WriteString("fn (")
+	str.WriteString("fn")
+	if f.Generator {
+		str.WriteString("*")
+	}
+	str.WriteString(" (")
 	str.WriteString(strings.Join(params, ","))
 	str.WriteString(") {\n")
 	str.WriteString(f.Body.String())
@@ -178,3 +547,66 @@ type Builtin struct {
 
 func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
 func (b *Builtin) Inspect() string  { return "builtin function" }
+
+// BoundMethod is a method looked up on a value (`value.method`), which calls
+// Method with Receiver prepended to its arguments.
+type BoundMethod struct {
+	Receiver Object
+	Name     string
+	Method   Object
+}
+
+func (m *BoundMethod) Type() ObjectType { return BOUND_METHOD_OBJ }
+func (m *BoundMethod) Inspect() string {
+	return fmt.Sprintf("bound method %s of %s", m.Name, m.Receiver.Type())
+}
+
+// Quote wraps an unevaluated AST node produced by a call to quote().
+type Quote struct {
+	Node ast.Node
+}
+
+func (q *Quote) Type() ObjectType { return QUOTE_OBJ }
+func (q *Quote) Inspect() string  { return "QUOTE(" + q.Node.String() + ")" }
+
+type Macro struct {
+	Parameters []*ast.Identifier
+	Body       *ast.BlockStatement
+	Env        *Environment
+}
+
+func (m *Macro) Type() ObjectType { return MACRO_OBJ }
+func (m *Macro) Inspect() string {
+	var str strings.Builder
+
+	params := make([]string, 0)
+	for _, param := range m.Parameters {
+		params = append(params, param.String())
+	}
+
+	str.WriteString("macro(")
+	str.WriteString(strings.Join(params, ","))
+	str.WriteString(") {\n")
+	str.WriteString(m.Body.String())
+	str.WriteString("}")
+
+	return str.String()
+}
+
+// Module is the result of evaluating an imported source file, holding the values
+// of the names it exports.
+type Module struct {
+	Path    string
+	Exports map[string]Object
+}
+
+func (m *Module) Type() ObjectType { return MODULE_OBJ }
+func (m *Module) Inspect() string {
+	names := make([]string, 0, len(m.Exports))
+	for name := range m.Exports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return fmt.Sprintf("module %q {%s}", m.Path, strings.Join(names, ", "))
+}
@@ -14,10 +14,13 @@ type (
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN      // a.b = c
+	TERNARY     // a ? b : c
+	NULLISH     // ??
 	EQUALS      // ==
-	LESSGREATER // < or >
-	SUM         // +
-	PRODUCT     // *
+	LESSGREATER // < or > or in
+	SUM         // + or |
+	PRODUCT     // * or &
 	PREFIX      // -- or ++
 	CALL        // function(X)
 	INDEX       // array[index]
@@ -25,14 +28,23 @@ const (
 
 // Association between the tokens and their defined precedence.
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LES:      LESSGREATER,
-	token.GRT:      LESSGREATER,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.LPAREN:   CALL,
-	token.LBRACKET: INDEX,
+	token.ASSIGN:       ASSIGN,
+	token.QUESTION:     TERNARY,
+	token.NULLISH:      NULLISH,
+	token.EQ:           EQUALS,
+	token.NOT_EQ:       EQUALS,
+	token.LES:          LESSGREATER,
+	token.GRT:          LESSGREATER,
+	token.IN:           LESSGREATER,
+	token.PLUS:         SUM,
+	token.MINUS:        SUM,
+	token.PIPE:         SUM,
+	token.SLASH:        PRODUCT,
+	token.ASTERISK:     PRODUCT,
+	token.AMP:          PRODUCT,
+	token.LPAREN:       CALL,
+	token.OPT_DOT:      CALL,
+	token.LBRACKET:     INDEX,
+	token.DOT:          INDEX,
+	token.OPT_LBRACKET: INDEX,
 }
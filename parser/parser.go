@@ -18,6 +18,9 @@ type Parser struct {
 
 	errors []error
 
+	// inGenerator is set while parsing the body of a generator function.
+	inGenerator bool
+
 	infixParseFns  map[token.TokenType]infixParseFn
 	prefixParseFns map[token.TokenType]prefixParseFn
 }
@@ -40,25 +43,42 @@ func (p *Parser) registerParseFns() {
 		token.MINUS:      p.parsePrefixExpression,
 		token.TRUE:       p.parseBoolean,
 		token.FALSE:      p.parseBoolean,
+		token.NULL:       p.parseNull,
 		token.LPAREN:     p.parseGroupedExpression,
 		token.IF:         p.parseIfExpression,
+		token.MATCH:      p.parseMatchExpression,
+		token.TRY:        p.parseTryExpression,
+		token.FOR:        p.parseForExpression,
 		token.FUNCTION:   p.parseFunction,
+		token.YIELD:      p.parseYieldExpression,
+		token.SPAWN:      p.parseSpawnExpression,
+		token.MACRO:      p.parseMacroLiteral,
 		token.STRING:     p.parseStringLiteral,
 		token.LBRACKET:   p.parseArrayLiteral,
 		token.LBRACE:     p.parseHashLiteral,
+		token.SET_BRACE:  p.parseSetLiteral,
 	}
 
 	p.infixParseFns = map[token.TokenType]infixParseFn{
-		token.PLUS:     p.parseInfixExpression,
-		token.MINUS:    p.parseInfixExpression,
-		token.SLASH:    p.parseInfixExpression,
-		token.ASTERISK: p.parseInfixExpression,
-		token.EQ:       p.parseInfixExpression,
-		token.NOT_EQ:   p.parseInfixExpression,
-		token.LES:      p.parseInfixExpression,
-		token.GRT:      p.parseInfixExpression,
-		token.LPAREN:   p.parseCallExpression,
-		token.LBRACKET: p.parseIndexExpression,
+		token.PLUS:         p.parseInfixExpression,
+		token.MINUS:        p.parseInfixExpression,
+		token.SLASH:        p.parseInfixExpression,
+		token.ASTERISK:     p.parseInfixExpression,
+		token.PIPE:         p.parseInfixExpression,
+		token.AMP:          p.parseInfixExpression,
+		token.IN:           p.parseInfixExpression,
+		token.EQ:           p.parseInfixExpression,
+		token.NOT_EQ:       p.parseInfixExpression,
+		token.LES:          p.parseInfixExpression,
+		token.GRT:          p.parseInfixExpression,
+		token.NULLISH:      p.parseInfixExpression,
+		token.QUESTION:     p.parseConditionalExpression,
+		token.ASSIGN:       p.parseAssignExpression,
+		token.LPAREN:       p.parseCallExpression,
+		token.LBRACKET:     p.parseIndexExpression,
+		token.OPT_LBRACKET: p.parseIndexExpression,
+		token.OPT_DOT:      p.parseOptionalChain,
+		token.DOT:          p.parseMemberExpression,
 	}
 }
 
@@ -68,7 +88,13 @@ func (p *Parser) ParseProgram() *ast.AST {
 	var statements []ast.Statement
 
 	for p.currentToken.Type != token.EOF {
-		statement := p.parseStatement()
+		var statement ast.Statement
+		if p.currentTokenIs(token.EXPORT) {
+			statement = p.parseExportStatement()
+		} else {
+			statement = p.parseStatement()
+		}
+
 		if statement != nil {
 			statements = append(statements, statement)
 		}
@@ -89,8 +115,21 @@ func (p *Parser) parseStatement() ast.Statement {
 	switch p.currentToken.Type {
 	case token.LET:
 		return p.parseLetStatement()
+	case token.STRUCT:
+		return p.parseStructStatement()
+	case token.CLASS:
+		return p.parseClassStatement()
+	case token.ENUM:
+		return p.parseEnumStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
+	case token.IMPORT:
+		return p.parseImportStatement()
+	case token.EXPORT:
+		p.errors = append(p.errors, fmt.Errorf("export statements are only allowed at the top level of a module"))
+		return nil
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -127,15 +166,20 @@ func (p *Parser) addExpectedTokenError(expected token.TokenType) {
 	p.errors = append(p.errors, msg)
 }
 
-// parses `let <identifier> = <expression>;` statements.
+// parses `let <identifier> = <expression>;` statements, where the identifier may
+// also be an array or hash pattern.
 func (p *Parser) parseLetStatement() ast.Statement {
 	stmt := &ast.LetStatement{Token: p.currentToken}
 
-	if !p.expectAndAdvance(token.IDENTIFIER) {
+	if !p.nextTokenIsBindingPattern() {
+		p.addExpectedTokenError(token.IDENTIFIER)
 		return nil
 	}
 
-	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}
+	p.advanceToken()
+	if stmt.Name = p.parsePattern(); stmt.Name == nil {
+		return nil
+	}
 
 	if !p.expectAndAdvance(token.ASSIGN) {
 		return nil
@@ -165,6 +209,285 @@ func (p *Parser) parseReturnStatement() ast.Statement {
 	return stmt
 }
 
+// parses `throw <expression>;` statements.
+func (p *Parser) parseThrowStatement() ast.Statement {
+	stmt := &ast.ThrowStatement{Token: p.currentToken}
+
+	p.advanceToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	if p.nextTokenIs(token.SEMICOLON) {
+		p.advanceToken()
+	}
+
+	return stmt
+}
+
+// parses `import "<path>" [as <identifier>];` and `import { <identifiers> } from "<path>";`
+// statements. `as` and `from` are only treated as keywords within import statements.
+func (p *Parser) parseImportStatement() ast.Statement {
+	stmt := &ast.ImportStatement{Token: p.currentToken}
+
+	if p.nextTokenIs(token.LBRACE) {
+		p.advanceToken()
+		stmt.Names = p.parseIdentifierList()
+		if stmt.Names == nil {
+			return nil
+		}
+
+		if !p.expectContextualKeyword("from") {
+			return nil
+		}
+	}
+
+	if !p.expectAndAdvance(token.STRING) {
+		return nil
+	}
+	stmt.Path = &ast.String{Token: p.currentToken, Value: p.currentToken.Value}
+
+	if stmt.Names == nil && p.nextTokenIs(token.IDENTIFIER) && p.nextToken.Value == "as" {
+		p.advanceToken()
+
+		if !p.expectAndAdvance(token.IDENTIFIER) {
+			return nil
+		}
+		stmt.Alias = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}
+	}
+
+	if p.nextTokenIs(token.SEMICOLON) {
+		p.advanceToken()
+	}
+
+	return stmt
+}
+
+// parses a comma separated list of identifiers up to a closing brace.
+func (p *Parser) parseIdentifierList() []*ast.Identifier {
+	names := []*ast.Identifier{}
+
+	for !p.nextTokenIs(token.RBRACE) {
+		if !p.expectAndAdvance(token.IDENTIFIER) {
+			return nil
+		}
+		names = append(names, &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value})
+
+		if !p.nextTokenIs(token.RBRACE) && !p.expectAndAdvance(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectAndAdvance(token.RBRACE) {
+		return nil
+	}
+
+	return names
+}
+
+// checks that the next token is an identifier with the given value (such as the
+// `from` in an import statement) and if so advances the parser past it.
+func (p *Parser) expectContextualKeyword(keyword string) bool {
+	if p.nextTokenIs(token.IDENTIFIER) && p.nextToken.Value == keyword {
+		p.advanceToken()
+		return true
+	}
+
+	p.errors = append(p.errors, fmt.Errorf("expected %q, got %s", keyword, p.nextToken))
+	return false
+}
+
+// parses `struct <identifier> { <identifiers> }` statements.
+func (p *Parser) parseStructStatement() ast.Statement {
+	stmt := &ast.StructStatement{Token: p.currentToken}
+
+	if !p.expectAndAdvance(token.IDENTIFIER) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}
+
+	if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+
+	if stmt.Fields = p.parseIdentifierList(); stmt.Fields == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range stmt.Fields {
+		if seen[field.Value] {
+			p.errors = append(p.errors, fmt.Errorf("duplicate field %s in struct %s", field, stmt.Name))
+			return nil
+		}
+		seen[field.Value] = true
+	}
+
+	if p.nextTokenIs(token.SEMICOLON) {
+		p.advanceToken()
+	}
+
+	return stmt
+}
+
+// parses `class <identifier> [extends <expression>] { <methods> }` statements.
+// `extends` is only treated as a keyword within class statements.
+func (p *Parser) parseClassStatement() ast.Statement {
+	stmt := &ast.ClassStatement{Token: p.currentToken}
+
+	if !p.expectAndAdvance(token.IDENTIFIER) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}
+
+	if p.nextTokenIs(token.IDENTIFIER) && p.nextToken.Value == "extends" {
+		p.advanceToken()
+		p.advanceToken()
+		if stmt.Parent = p.parseExpression(LOWEST); stmt.Parent == nil {
+			return nil
+		}
+	}
+
+	if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Methods = []*ast.ClassMethod{}
+	for !p.nextTokenIs(token.RBRACE) {
+		method := p.parseClassMethod()
+		if method == nil {
+			return nil
+		}
+		stmt.Methods = append(stmt.Methods, method)
+	}
+	p.advanceToken()
+
+	if p.nextTokenIs(token.SEMICOLON) {
+		p.advanceToken()
+	}
+
+	return stmt
+}
+
+// parses `<identifier>(<parameters>) { <body> }` within a class body.
+func (p *Parser) parseClassMethod() *ast.ClassMethod {
+	if !p.expectAndAdvance(token.IDENTIFIER) {
+		return nil
+	}
+	method := &ast.ClassMethod{Name: &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}}
+
+	if !p.expectAndAdvance(token.LPAREN) {
+		return nil
+	}
+
+	if method.Parameters = p.parseFunctionParameters(); method.Parameters == nil {
+		return nil
+	}
+
+	if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+	method.Body = p.parseFunctionBody(false)
+
+	return method
+}
+
+// parses `enum <identifier> { <variant>, ... }` statements, where each variant is
+// an identifier optionally followed by a parenthesized list of field names.
+func (p *Parser) parseEnumStatement() ast.Statement {
+	stmt := &ast.EnumStatement{Token: p.currentToken}
+
+	if !p.expectAndAdvance(token.IDENTIFIER) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}
+
+	if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for !p.nextTokenIs(token.RBRACE) {
+		variant := p.parseEnumVariant()
+		if variant == nil {
+			return nil
+		}
+
+		if seen[variant.Name.Value] {
+			p.errors = append(p.errors, fmt.Errorf("duplicate variant %s in enum %s", variant.Name, stmt.Name))
+			return nil
+		}
+		seen[variant.Name.Value] = true
+		stmt.Variants = append(stmt.Variants, variant)
+
+		if !p.nextTokenIs(token.RBRACE) && !p.expectAndAdvance(token.COMMA) {
+			return nil
+		}
+	}
+	p.advanceToken()
+
+	if p.nextTokenIs(token.SEMICOLON) {
+		p.advanceToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseEnumVariant() *ast.EnumVariant {
+	if !p.expectAndAdvance(token.IDENTIFIER) {
+		return nil
+	}
+	variant := &ast.EnumVariant{Name: &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}}
+
+	if !p.nextTokenIs(token.LPAREN) {
+		return variant
+	}
+	p.advanceToken()
+
+	variant.Fields = []*ast.Identifier{}
+	for !p.nextTokenIs(token.RPAREN) {
+		if !p.expectAndAdvance(token.IDENTIFIER) {
+			return nil
+		}
+		variant.Fields = append(variant.Fields, &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value})
+
+		if !p.nextTokenIs(token.RPAREN) && !p.expectAndAdvance(token.COMMA) {
+			return nil
+		}
+	}
+	p.advanceToken()
+
+	return variant
+}
+
+// parses `export` followed by a let, struct, class or enum statement.
+func (p *Parser) parseExportStatement() ast.Statement {
+	stmt := &ast.ExportStatement{Token: p.currentToken}
+
+	var declaration ast.Statement
+	switch {
+	case p.nextTokenIs(token.STRUCT):
+		p.advanceToken()
+		declaration = p.parseStructStatement()
+	case p.nextTokenIs(token.CLASS):
+		p.advanceToken()
+		declaration = p.parseClassStatement()
+	case p.nextTokenIs(token.ENUM):
+		p.advanceToken()
+		declaration = p.parseEnumStatement()
+	default:
+		if !p.expectAndAdvance(token.LET) {
+			return nil
+		}
+		declaration = p.parseLetStatement()
+	}
+
+	if declaration == nil {
+		return nil
+	}
+	stmt.Statement = declaration
+
+	return stmt
+}
+
 // parses `<expression>;` statements.
 func (p *Parser) parseExpressionStatement() ast.Statement {
 	statement := &ast.ExpressionStatement{
@@ -204,11 +527,36 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 	return leftExpr
 }
 
+// parseGroupedExpression parses a parenthesized expression, or a tuple if the
+// parentheses are empty or contain a comma (`()`, `(a,)`, `(a, b)`).
 func (p *Parser) parseGroupedExpression() ast.Expression {
+	tok := p.currentToken
+
+	if p.nextTokenIs(token.RPAREN) {
+		p.advanceToken()
+		return &ast.Tuple{Token: tok, Elements: []ast.Expression{}}
+	}
+
 	p.advanceToken()
 
 	exp := p.parseExpression(LOWEST)
 
+	if p.nextTokenIs(token.COMMA) {
+		tuple := &ast.Tuple{Token: tok, Elements: []ast.Expression{exp}}
+
+		for p.nextTokenIs(token.COMMA) {
+			p.advanceToken()
+			if p.nextTokenIs(token.RPAREN) {
+				break
+			}
+
+			p.advanceToken()
+			tuple.Elements = append(tuple.Elements, p.parseExpression(LOWEST))
+		}
+
+		exp = tuple
+	}
+
 	if !p.expectAndAdvance(token.RPAREN) {
 		return nil
 	}
@@ -286,6 +634,54 @@ func (p *Parser) parseBoolean() ast.Expression {
 	}
 }
 
+func (p *Parser) parseNull() ast.Expression {
+	return &ast.Null{Token: p.currentToken}
+}
+
+// parses `<target> = <value>`. Assignment is right associative, so `a.x = b.y = 1`
+// assigns 1 to both. Only members and indexes can be assigned to; variables are
+// rebound with let.
+func (p *Parser) parseAssignExpression(target ast.Expression) ast.Expression {
+	exp := &ast.AssignExpression{Token: p.currentToken, Target: target}
+
+	switch target := target.(type) {
+	case nil:
+		return nil
+	case *ast.IndexExpression:
+		if target.Optional {
+			p.errors = append(p.errors, fmt.Errorf("cannot assign to %s", target))
+			return nil
+		}
+	case *ast.MemberExpression:
+		if target.Optional {
+			p.errors = append(p.errors, fmt.Errorf("cannot assign to %s", target))
+			return nil
+		}
+	default:
+		p.errors = append(p.errors, fmt.Errorf("cannot assign to %s", target))
+		return nil
+	}
+
+	p.advanceToken()
+	exp.Value = p.parseExpression(ASSIGN - 1)
+
+	return exp
+}
+
+// parses `spawn <expression>`. The expression binds as tightly as a prefix
+// operator's operand, so `spawn f(x)` spawns the call rather than calling the
+// result of `spawn f`.
+func (p *Parser) parseSpawnExpression() ast.Expression {
+	exp := &ast.SpawnExpression{Token: p.currentToken}
+
+	p.advanceToken()
+	if exp.Call = p.parseExpression(PREFIX); exp.Call == nil {
+		return nil
+	}
+
+	return exp
+}
+
 func (p *Parser) parseIfExpression() ast.Expression {
 	exp := &ast.IfExpression{Token: p.currentToken}
 
@@ -308,6 +704,18 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	if p.nextTokenIs(token.ELSE) {
 		p.advanceToken()
 
+		if p.nextTokenIs(token.IF) {
+			p.advanceToken()
+
+			elseIf, ok := p.parseIfExpression().(*ast.IfExpression)
+			if !ok {
+				return nil
+			}
+
+			exp.ElseIf = elseIf
+			return exp
+		}
+
 		if !p.expectAndAdvance(token.LBRACE) {
 			return nil
 		}
@@ -318,6 +726,129 @@ func (p *Parser) parseIfExpression() ast.Expression {
 	return exp
 }
 
+// parses `<condition> ? <consequence> : <alternative>`. The alternative is parsed
+// at the lowest precedence so that chained conditionals associate to the right.
+func (p *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	exp := &ast.ConditionalExpression{Token: p.currentToken, Condition: condition}
+
+	p.advanceToken()
+	exp.Consequence = p.parseExpression(LOWEST)
+
+	if !p.expectAndAdvance(token.COLON) {
+		return nil
+	}
+
+	p.advanceToken()
+	exp.Alternative = p.parseExpression(LOWEST)
+
+	return exp
+}
+
+// parses `try { } catch (<pattern>) { } finally { }`, where either the catch or
+// finally block may be omitted (but not both).
+func (p *Parser) parseTryExpression() ast.Expression {
+	exp := &ast.TryExpression{Token: p.currentToken}
+
+	if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+
+	exp.Body = p.parseBlockStatement()
+
+	if p.nextTokenIs(token.CATCH) {
+		p.advanceToken()
+
+		if p.nextTokenIs(token.LPAREN) {
+			p.advanceToken()
+
+			if !p.nextTokenIsBindingPattern() {
+				p.addExpectedTokenError(token.IDENTIFIER)
+				return nil
+			}
+
+			p.advanceToken()
+			if exp.CatchParam = p.parsePattern(); exp.CatchParam == nil {
+				return nil
+			}
+
+			if !p.expectAndAdvance(token.RPAREN) {
+				return nil
+			}
+		}
+
+		if !p.expectAndAdvance(token.LBRACE) {
+			return nil
+		}
+
+		exp.Catch = p.parseBlockStatement()
+	}
+
+	if p.nextTokenIs(token.FINALLY) {
+		p.advanceToken()
+
+		if !p.expectAndAdvance(token.LBRACE) {
+			return nil
+		}
+
+		exp.Finally = p.parseBlockStatement()
+	}
+
+	if exp.Catch == nil && exp.Finally == nil {
+		p.errors = append(p.errors, fmt.Errorf("try expression requires a catch or finally block"))
+		return nil
+	}
+
+	return exp
+}
+
+func (p *Parser) parseForExpression() ast.Expression {
+	exp := &ast.ForExpression{Token: p.currentToken}
+
+	if !p.expectAndAdvance(token.LPAREN) {
+		return nil
+	}
+
+	for len(exp.Variables) == 0 || p.nextTokenIs(token.COMMA) {
+		if len(exp.Variables) == 2 {
+			p.errors = append(p.errors, fmt.Errorf("for loops bind at most two variables"))
+			return nil
+		}
+		if len(exp.Variables) > 0 {
+			p.advanceToken()
+		}
+
+		if !p.nextTokenIsBindingPattern() {
+			p.addExpectedTokenError(token.IDENTIFIER)
+			return nil
+		}
+
+		p.advanceToken()
+		variable := p.parsePattern()
+		if variable == nil {
+			return nil
+		}
+		exp.Variables = append(exp.Variables, variable)
+	}
+
+	if !p.expectAndAdvance(token.IN) {
+		return nil
+	}
+
+	p.advanceToken()
+	exp.Iterable = p.parseExpression(LOWEST)
+
+	if !p.expectAndAdvance(token.RPAREN) {
+		return nil
+	}
+	if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+
+	exp.Body = p.parseBlockStatement()
+
+	return exp
+}
+
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{
 		Token:      p.currentToken,
@@ -344,6 +875,11 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 func (p *Parser) parseFunction() ast.Expression {
 	f := &ast.Function{Token: p.currentToken}
 
+	if p.nextTokenIs(token.ASTERISK) {
+		p.advanceToken()
+		f.Generator = true
+	}
+
 	if !p.expectAndAdvance(token.LPAREN) {
 		return nil
 	}
@@ -354,42 +890,105 @@ func (p *Parser) parseFunction() ast.Expression {
 		return nil
 	}
 
-	f.Body = p.parseBlockStatement()
+	f.Body = p.parseFunctionBody(f.Generator)
 
 	return f
 }
 
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := []*ast.Identifier{}
+// parses the body of a function, which may only contain yield expressions if the
+// function is a generator.
+func (p *Parser) parseFunctionBody(generator bool) *ast.BlockStatement {
+	enclosing := p.inGenerator
+	p.inGenerator = generator
+	defer func() { p.inGenerator = enclosing }()
 
-	if p.nextTokenIs(token.RPAREN) {
-		p.advanceToken()
-		return identifiers
+	return p.parseBlockStatement()
+}
+
+// parses `yield [<expression>]`.
+func (p *Parser) parseYieldExpression() ast.Expression {
+	exp := &ast.YieldExpression{Token: p.currentToken}
+
+	if !p.inGenerator {
+		p.errors = append(p.errors, fmt.Errorf("yield is only allowed in generator functions"))
+		return nil
+	}
+
+	if p.nextTokenIs(token.SEMICOLON) || p.nextTokenIs(token.RBRACE) {
+		return exp
 	}
 
 	p.advanceToken()
+	exp.Value = p.parseExpression(LOWEST)
 
-	identifiers = append(identifiers, &ast.Identifier{
-		Token: p.currentToken,
-		Value: p.currentToken.Value,
-	})
+	return exp
+}
 
-	// Continue reading the list of parameters until we hit the ).
-	for p.nextTokenIs(token.COMMA) {
+func (p *Parser) parseMacroLiteral() ast.Expression {
+	macro := &ast.MacroLiteral{Token: p.currentToken}
+
+	if !p.expectAndAdvance(token.LPAREN) {
+		return nil
+	}
+
+	parameters := p.parseFunctionParameters()
+	if parameters == nil {
+		return nil
+	}
+
+	// Macro arguments are passed as quoted AST nodes, so they can't be destructured.
+	for _, parameter := range parameters {
+		identifier, ok := parameter.(*ast.Identifier)
+		if !ok {
+			p.errors = append(p.errors, fmt.Errorf("macro parameters must be identifiers, got %s", parameter))
+			return nil
+		}
+		macro.Parameters = append(macro.Parameters, identifier)
+	}
+
+	if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+
+	macro.Body = p.parseFunctionBody(false)
+
+	return macro
+}
+
+func (p *Parser) parseFunctionParameters() []ast.Pattern {
+	parameters := []ast.Pattern{}
+
+	if p.nextTokenIs(token.RPAREN) {
 		p.advanceToken()
+		return parameters
+	}
+
+	// Continue reading the list of parameters until we hit the ).
+	for {
+		if !p.nextTokenIsBindingPattern() {
+			p.addExpectedTokenError(token.IDENTIFIER)
+			return nil
+		}
+
 		p.advanceToken()
 
-		identifiers = append(identifiers, &ast.Identifier{
-			Token: p.currentToken,
-			Value: p.currentToken.Value,
-		})
+		parameter := p.parsePattern()
+		if parameter == nil {
+			return nil
+		}
+		parameters = append(parameters, parameter)
+
+		if !p.nextTokenIs(token.COMMA) {
+			break
+		}
+		p.advanceToken()
 	}
 
 	if !p.expectAndAdvance(token.RPAREN) {
 		return nil
 	}
 
-	return identifiers
+	return parameters
 }
 
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
@@ -409,6 +1008,15 @@ func (p *Parser) parseArrayLiteral() ast.Expression {
 	return &ast.Array{Token: p.currentToken, Elements: elements}
 }
 
+func (p *Parser) parseSetLiteral() ast.Expression {
+	tok := p.currentToken
+	elements := p.parseExpressionList(token.RBRACE)
+	if elements == nil {
+		return nil
+	}
+	return &ast.Set{Token: tok, Elements: elements}
+}
+
 func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	list := []ast.Expression{}
 
@@ -434,11 +1042,31 @@ func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	return list
 }
 
+// parseIndexExpression parses `left[index]` as well as slices (`left[low:high]`),
+// which are told apart by the presence of a colon after the (optional) low bound.
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
-	exp := &ast.IndexExpression{Token: p.currentToken, Left: left}
+	tok := p.currentToken
+	optional := p.currentTokenIs(token.OPT_LBRACKET)
 
 	p.advanceToken()
-	exp.Index = p.parseExpression(LOWEST)
+
+	var index ast.Expression
+	if !p.currentTokenIs(token.COLON) {
+		index = p.parseExpression(LOWEST)
+		if !p.nextTokenIs(token.COLON) {
+			if !p.expectAndAdvance(token.RBRACKET) {
+				return nil
+			}
+			return &ast.IndexExpression{Token: tok, Left: left, Index: index, Optional: optional}
+		}
+		p.advanceToken()
+	}
+
+	exp := &ast.SliceExpression{Token: tok, Left: left, Low: index, Optional: optional}
+	if !p.nextTokenIs(token.RBRACKET) {
+		p.advanceToken()
+		exp.High = p.parseExpression(LOWEST)
+	}
 
 	if !p.expectAndAdvance(token.RBRACKET) {
 		return nil
@@ -447,10 +1075,49 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	return exp
 }
 
+func (p *Parser) parseMemberExpression(object ast.Expression) ast.Expression {
+	tok := p.currentToken
+
+	if !p.expectAndAdvance(token.IDENTIFIER) {
+		return nil
+	}
+
+	return &ast.MemberExpression{
+		Token:    tok,
+		Object:   object,
+		Property: &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value},
+	}
+}
+
+// parses the part of an optional chain following `?.`, which is either the
+// argument list of an optional call or the name of a member.
+func (p *Parser) parseOptionalChain(left ast.Expression) ast.Expression {
+	tok := p.currentToken
+
+	switch {
+	case p.nextTokenIs(token.LPAREN):
+		p.advanceToken()
+		return &ast.CallExpression{
+			Token:     tok,
+			Function:  left,
+			Arguments: p.parseExpressionList(token.RPAREN),
+			Optional:  true,
+		}
+	case p.expectAndAdvance(token.IDENTIFIER):
+		return &ast.MemberExpression{
+			Token:    tok,
+			Object:   left,
+			Property: &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value},
+			Optional: true,
+		}
+	}
+	return nil
+}
+
 func (p *Parser) parseHashLiteral() ast.Expression {
 	hash := &ast.Hash{
 		Token: p.currentToken,
-		Pairs: make(map[ast.Expression]ast.Expression),
+		Pairs: []*ast.HashPair{},
 	}
 
 	for !p.nextTokenIs(token.RBRACE) {
@@ -463,7 +1130,7 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		}
 
 		p.advanceToken()
-		hash.Pairs[key] = p.parseExpression(LOWEST)
+		hash.Pairs = append(hash.Pairs, &ast.HashPair{Key: key, Value: p.parseExpression(LOWEST)})
 
 		if !p.nextTokenIs(token.RBRACE) && !p.expectAndAdvance(token.COMMA) {
 			return nil
@@ -328,6 +328,286 @@ func TestIfElseExpression(t *testing.T) {
 	}
 }
 
+func TestElseIfExpression(t *testing.T) {
+	input := `if (x < y) { x } else if (x > y) { y } else { z }`
+
+	p := New(lexer.New(input))
+	parsedProgram := p.ParseProgram()
+	checkParserHasNoErrors(t, p)
+
+	stmt := parsedProgram.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.IfExpression. got=%T", stmt.Expression)
+	}
+
+	if exp.Alternative != nil {
+		t.Errorf("exp.Alternative was not nil. got=%+v", exp.Alternative)
+	}
+
+	if exp.ElseIf == nil {
+		t.Fatalf("exp.ElseIf was nil")
+	}
+
+	if !testInfixExpression(t, exp.ElseIf.Condition, "x", ">", "y") {
+		return
+	}
+
+	consequence := exp.ElseIf.Consequence.Statements[0].(*ast.ExpressionStatement)
+	if !testIdentifierLiteral(t, consequence.Expression, "y") {
+		return
+	}
+
+	if exp.ElseIf.Alternative == nil {
+		t.Fatalf("exp.ElseIf.Alternative was nil")
+	}
+
+	alternative := exp.ElseIf.Alternative.Statements[0].(*ast.ExpressionStatement)
+	testIdentifierLiteral(t, alternative.Expression, "z")
+}
+
+func TestTryExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"try { a } catch (e) { b }", "try acatch (e) b"},
+		{"try { a } catch { b }", "try acatch b"},
+		{"try { a } finally { c }", "try afinally c"},
+		{"try { a } catch ({message}) { message } finally { c }", "try acatch ({message:message}) messagefinally c"},
+		{`throw "boom";`, "throw boom;"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	p := New(lexer.New("try { a }"))
+	p.ParseProgram()
+
+	expectedError := "try expression requires a catch or finally block"
+	if len(p.Errors()) != 1 || p.Errors()[0].Error() != expectedError {
+		t.Errorf("expected error %q, got %v", expectedError, p.Errors())
+	}
+}
+
+func TestForExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"for (x in xs) { x }", "for (x in xs) x"},
+		{"for (k, v in h) { k + v }", "for (k, v in h) (k + v)"},
+		{"for ([a, b] in pairs) { a }", "for ([a, b] in pairs) a"},
+		{"for (x in range(3)) { }", "for (x in range(3)) "},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"for (a, b, c in xs) { }", "for loops bind at most two variables"},
+		{"for (x of xs) { }", "expected token IN, got {IDENTIFIER of}"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0].Error() != tt.expected {
+			t.Errorf("expected error %q, got %v", tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestStructAndAssignParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"struct Point { x, y }", "struct Point { x, y }"},
+		{"struct Empty {};", "struct Empty {}"},
+		{"p.x = 1", "((p.x) = 1)"},
+		{"a[0] = b[1] = c", "((a[0]) = ((b[1]) = c))"},
+		{"p.x = y ? 1 : 2", "((p.x) = (y ? 1 : 2))"},
+		{"p.x = p.x + 1", "((p.x) = ((p.x) + 1))"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"struct { x }", "expected token IDENTIFIER, got {{ {}"},
+		{"struct Point { x, x }", "duplicate field x in struct Point"},
+		{"x = 1", "cannot assign to x"},
+		{"p?.x = 1", "cannot assign to (p?.x)"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0].Error() != tt.expected {
+			t.Errorf("expected error %q, got %v", tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestClassParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"class A {}", "class A { }"},
+		{"class A { init(x) { self.x = x } get() { self.x } }", "class A { init(x) ((self.x) = x) get() (self.x) }"},
+		{"class B extends lib.A { f([a, b]) { a } };", "class B extends (lib.A) { f([a, b]) a }"},
+		{"let extends = 1", "let extends = 1;"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"class { }", "expected token IDENTIFIER, got {{ {}"},
+		{"class A { fn f() {} }", "expected token IDENTIFIER, got {FUNCTION fn}"},
+		{"class A { f {} }", "expected token (, got {{ {}"},
+		{"class A from B {}", "expected token {, got {IDENTIFIER from}"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0].Error() != tt.expected {
+			t.Errorf("expected error %q, got %v", tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestEnumParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"enum Shape { Circle(r), Rect(w, h), Empty }", "enum Shape { Circle(r), Rect(w, h), Empty }"},
+		{"enum E { A(), B, };", "enum E { A(), B }"},
+		{"match (s) { Shape.Circle(r) => r, Shape.Empty => 0, lib.Shape.Rect(_, [h]) => h }",
+			"match (s) {(Shape.Circle)(r) => r, (Shape.Empty) => 0, ((lib.Shape).Rect)(_, [h]) => h}"},
+		{"let Point(x, y) = p", "let Point(x, y) = p;"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []struct {
+		input    string
+		expected string
+	}{
+		{"enum E { A, A }", "duplicate variant A in enum E"},
+		{"enum E { A(1) }", "expected token IDENTIFIER, got {INTEGER 1}"},
+		{"enum E { A B }", "expected token ,, got {IDENTIFIER B}"},
+		{"match (s) { E.A(1 => 1 }", "expected token ,, got {=> =>}"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0].Error() != tt.expected {
+			t.Errorf("expected error %q, got %v", tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestImportExportParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "path/to/lib.mk" as lib;`, `import "path/to/lib.mk" as lib;`},
+		{`import "lib"`, `import "lib";`},
+		{`import { a, b } from "lib";`, `import {a, b} from "lib";`},
+		{`import {} from "lib";`, `import {} from "lib";`},
+		{`export let x = 5;`, `export let x = 5;`},
+		{`export struct Point { x, y }`, `export struct Point { x, y }`},
+		{`export class A {}`, `export class A { }`},
+		{`export enum E { A }`, `export enum E { A }`},
+		{`let as = 1; let from = 2;`, `let as = 1;let from = 2;`},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []struct {
+		input         string
+		expectedError string
+	}{
+		{`fn() { export let x = 5; }`, "export statements are only allowed at the top level of a module"},
+		{`export x;`, "expected token LET, got {IDENTIFIER x}"},
+		{`import { a } "lib";`, `expected "from", got {STRING lib}`},
+		{`import lib;`, "expected token STRING, got {IDENTIFIER lib}"},
+	}
+
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 || p.Errors()[0].Error() != tt.expectedError {
+			t.Errorf("expected error %q for %q, got %v", tt.expectedError, tt.input, p.Errors())
+		}
+	}
+}
+
 func TestCallExpressionParsing(t *testing.T) {
 	input := "add(1, 2 * 3, 4 + 5);"
 
@@ -412,6 +692,36 @@ func TestParsingIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestParsingSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"myArray[1:3]", "(myArray[1:3])"},
+		{"myArray[:n]", "(myArray[:n])"},
+		{"myArray[-2:]", "(myArray[(-2):])"},
+		{"myArray[:]", "(myArray[:])"},
+		{"myArray?[1 + 1:len(myArray)]", "(myArray?[(1 + 1):len(myArray)])"},
+		{"myArray[a ? 1 : 2:]", "(myArray[(a ? 1 : 2):])"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			p := New(lexer.New(tt.input))
+			program := p.ParseProgram()
+			checkParserHasNoErrors(t, p)
+
+			stmt := program.Statements[0].(*ast.ExpressionStatement)
+			if _, ok := stmt.Expression.(*ast.SliceExpression); !ok {
+				t.Fatalf("exp not *ast.SliceExpression. got=%T", stmt.Expression)
+			}
+			if stmt.Expression.String() != tt.expected {
+				t.Errorf("expected=%q, got=%q", tt.expected, stmt.Expression.String())
+			}
+		})
+	}
+}
+
 func TestFunctionLiteralParsing(t *testing.T) {
 	input := `fn(x, y) { x + y; }`
 
@@ -452,6 +762,100 @@ func TestFunctionLiteralParsing(t *testing.T) {
 	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
 }
 
+func TestMacroLiteralParsing(t *testing.T) {
+	input := `macro(x, y) { x + y; }`
+
+	p := New(lexer.New(input))
+	program := p.ParseProgram()
+	checkParserHasNoErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	macro, ok := stmt.Expression.(*ast.MacroLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.MacroLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(macro.Parameters) != 2 {
+		t.Fatalf("macro literal parameters wrong. want 2, got=%d\n", len(macro.Parameters))
+	}
+
+	testLiteralExpression(t, macro.Parameters[0], "x")
+	testLiteralExpression(t, macro.Parameters[1], "y")
+
+	bodyStmt := macro.Body.Statements[0].(*ast.ExpressionStatement)
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+
+	p = New(lexer.New("macro([x]) { x }"))
+	p.ParseProgram()
+
+	expectedError := "macro parameters must be identifiers, got [x]"
+	if len(p.Errors()) == 0 || p.Errors()[0].Error() != expectedError {
+		t.Errorf("expected error %q, got %v", expectedError, p.Errors())
+	}
+}
+
+func TestGeneratorParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn*(n) { yield n; yield }", "func* (n)yield nyield"},
+		{"fn*() { yield a + 1 }", "func* ()yield (a + 1)"},
+		{"fn*() { fn*() { yield 1 } }", "func* ()func* ()yield 1"},
+		{"fn*() { for (x in xs) { yield x } }", "func* ()for (x in xs) yield x"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	errorTests := []string{
+		"yield 1",
+		"fn() { yield 1 }",
+		"fn*() { fn() { yield 1 } }",
+		"fn*() { macro(x) { yield x } }",
+		"fn*() { class A { f() { yield 1 } } }",
+	}
+
+	for _, input := range errorTests {
+		p := New(lexer.New(input))
+		p.ParseProgram()
+
+		expected := "yield is only allowed in generator functions"
+		if len(p.Errors()) == 0 || p.Errors()[0].Error() != expected {
+			t.Errorf("expected error %q for %q, got %v", expected, input, p.Errors())
+		}
+	}
+}
+
+func TestSpawnParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"spawn f(1, 2)", "spawn f(1, 2)"},
+		{"spawn lib.f(x)", "spawn (lib.f)(x)"},
+		{"let t = spawn fn() { 1 }", "let t = spawn func ()1;"},
+		{"spawn f(1) + 1", "(spawn f(1) + 1)"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
 func TestFunctionParameterParsing(t *testing.T) {
 	tests := []struct {
 		input          string
@@ -510,6 +914,29 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 		{"add(a + b + c * d / f + g)", "add((((a + b) + ((c * d) / f)) + g))"},
 		{"a * [1, 2, 3, 4][b * c] * d", "((a * ([1, 2, 3, 4][(b * c)])) * d)"},
 		{"add(a * b[2], b[1], 2 * [1, 2][1])", "add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))"},
+		{"a ?? b == c", "(a ?? (b == c))"},
+		{"a ?? b ?? c", "((a ?? b) ?? c)"},
+		{"a?.b?[c] + 1", "(((a?.b)?[c]) + 1)"},
+		{"a?.b?.(c, d)", "(a?.b)?.(c, d)"},
+		{"a ? b : c", "(a ? b : c)"},
+		{"a < b ? a + 1 : b * 2", "((a < b) ? (a + 1) : (b * 2))"},
+		{"a ? b : c ? d : e", "(a ? b : (c ? d : e))"},
+		{"a ? b ? c : d : e", "(a ? (b ? c : d) : e)"},
+		{"a ?? b ? c : d", "((a ?? b) ? c : d)"},
+		{"add(a ? b : c, d)", "add((a ? b : c), d)"},
+		{"a + c ?[1]:[2]", "((a + c) ? [1] : [2])"},
+		{"c ?[[1], 2] : []", "(c ? [[1], 2] : [])"},
+		{"c ?[a?[0]] : b?[1]", "(c ? [(a?[0])] : (b?[1]))"},
+		{"a | b & c", "(a | (b & c))"},
+		{"a - b | c", "((a - b) | c)"},
+		{"a in b == c in d", "((a in b) == (c in d))"},
+		{"a + 1 in b", "((a + 1) in b)"},
+		{"!(a in b)", "(!(a in b))"},
+		{"a.b.c", "((a.b).c)"},
+		{"a.b(c).d", "((a.b)(c).d)"},
+		{"-a.b * c", "((-(a.b)) * c)"},
+		{"a.b[c]", "((a.b)[c])"},
+		{"a?.b.c", "((a?.b).c)"},
 	}
 
 	for _, tt := range tests {
@@ -525,6 +952,43 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 	}
 }
 
+func TestNullExpression(t *testing.T) {
+	p := New(lexer.New("null;"))
+	parsedProgram := p.ParseProgram()
+	checkParserHasNoErrors(t, p)
+
+	stmt := parsedProgram.Statements[0].(*ast.ExpressionStatement)
+	if _, ok := stmt.Expression.(*ast.Null); !ok {
+		t.Fatalf("exp not *ast.Null. got=%T", stmt.Expression)
+	}
+}
+
+func TestOptionalChainParsing(t *testing.T) {
+	p := New(lexer.New("config?.db?[key]?.(1)"))
+	parsedProgram := p.ParseProgram()
+	checkParserHasNoErrors(t, p)
+
+	stmt := parsedProgram.Statements[0].(*ast.ExpressionStatement)
+
+	call, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok || !call.Optional {
+		t.Fatalf("exp not an optional *ast.CallExpression. got=%T (%+v)", stmt.Expression, stmt.Expression)
+	}
+
+	index, ok := call.Function.(*ast.IndexExpression)
+	if !ok || !index.Optional {
+		t.Fatalf("call.Function not an optional *ast.IndexExpression. got=%T (%+v)", call.Function, call.Function)
+	}
+	testIdentifierLiteral(t, index.Index, "key")
+
+	member, ok := index.Left.(*ast.MemberExpression)
+	if !ok || !member.Optional {
+		t.Fatalf("index.Left not an optional *ast.MemberExpression. got=%T (%+v)", index.Left, index.Left)
+	}
+	testIdentifierLiteral(t, member.Object, "config")
+	testIdentifierLiteral(t, member.Property, "db")
+}
+
 func TestStringLiteralExpression(t *testing.T) {
 	input := `"hello world"`
 
@@ -610,16 +1074,60 @@ func TestParsingHashLiteralsStringKeys(t *testing.T) {
 		t.Errorf("hash.Pairs has wrong length. got=%d", len(hash.Pairs))
 	}
 
-	expected := map[string]int64{"one": 1, "two": 2, "three": 3}
+	expected := []struct {
+		key   string
+		value int64
+	}{{"one", 1}, {"two", 2}, {"three", 3}}
 
-	for key, value := range hash.Pairs {
-		literal, ok := key.(*ast.String)
+	for i, pair := range hash.Pairs {
+		literal, ok := pair.Key.(*ast.String)
 
 		if !ok {
-			t.Errorf("key is not ast.StringLiteral. got=%T", key)
+			t.Errorf("key is not ast.StringLiteral. got=%T", pair.Key)
+			continue
+		}
+		if literal.String() != expected[i].key {
+			t.Errorf("key %d is wrong. expected=%q, got=%q", i, expected[i].key, literal.String())
+		}
+		testIntegerLiteral(t, pair.Value, expected[i].value)
+	}
+}
+
+func TestHashLiteralString(t *testing.T) {
+	input := `{"c": 1, "a": 2 + 3, b: fn(x) { x }}`
+	p := New(lexer.New(input))
+	program := p.ParseProgram()
+	checkParserHasNoErrors(t, p)
+
+	expected := "{c:1, a:(2 + 3), b:func (x)x}"
+	if program.String() != expected {
+		t.Errorf("expected=%q, got=%q", expected, program.String())
+	}
+}
+
+func TestSetAndTupleLiteralParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"#{1, 2 + 3, a}", "#{1, (2 + 3), a}"},
+		{"#{}", "#{}"},
+		{"(1, 2)", "(1, 2)"},
+		{"(1, 2,)", "(1, 2)"},
+		{"(a,)", "(a,)"},
+		{"()", "()"},
+		{"(a)", "a"},
+		{"((1, 2), #{(3, 4)})", "((1, 2), #{(3, 4)})"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
 		}
-		expectedValue := expected[literal.String()]
-		testIntegerLiteral(t, value, expectedValue)
 	}
 }
 
@@ -670,11 +1178,11 @@ func TestParsingHashLiteralsWithExpressions(t *testing.T) {
 		},
 	}
 
-	for key, value := range hash.Pairs {
-		literal, ok := key.(*ast.String)
+	for _, pair := range hash.Pairs {
+		literal, ok := pair.Key.(*ast.String)
 
 		if !ok {
-			t.Errorf("key is not ast.StringLiteral. got=%T", key)
+			t.Errorf("key is not ast.StringLiteral. got=%T", pair.Key)
 			continue
 		}
 
@@ -685,7 +1193,7 @@ func TestParsingHashLiteralsWithExpressions(t *testing.T) {
 			continue
 		}
 
-		testFunc(value)
+		testFunc(pair.Value)
 	}
 }
 
@@ -0,0 +1,266 @@
+package parser
+
+import (
+	"fmt"
+	"monkey-interpreter/ast"
+	"monkey-interpreter/token"
+)
+
+// parses `match (<expression>) { <pattern> [if <guard>] => <body>, ... }`.
+func (p *Parser) parseMatchExpression() ast.Expression {
+	exp := &ast.MatchExpression{Token: p.currentToken}
+
+	if !p.expectAndAdvance(token.LPAREN) {
+		return nil
+	}
+
+	p.advanceToken()
+	exp.Subject = p.parseExpression(LOWEST)
+
+	if !p.expectAndAdvance(token.RPAREN) {
+		return nil
+	} else if !p.expectAndAdvance(token.LBRACE) {
+		return nil
+	}
+
+	p.advanceToken()
+
+	for !p.currentTokenIs(token.RBRACE) {
+		if p.currentTokenIs(token.EOF) {
+			p.errors = append(p.errors, fmt.Errorf("unterminated match expression"))
+			return nil
+		}
+
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		exp.Arms = append(exp.Arms, arm)
+
+		p.advanceToken()
+		if p.currentTokenIs(token.COMMA) {
+			p.advanceToken()
+		}
+	}
+
+	return exp
+}
+
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{Pattern: p.parsePattern()}
+	if arm.Pattern == nil {
+		return nil
+	}
+
+	if p.nextTokenIs(token.IF) {
+		p.advanceToken()
+		p.advanceToken()
+		arm.Guard = p.parseExpression(LOWEST)
+	}
+
+	if !p.expectAndAdvance(token.ARROW) {
+		return nil
+	}
+
+	// A brace following the arrow always starts a block; hash literals used as
+	// the result of an arm need to be wrapped in parentheses.
+	if p.nextTokenIs(token.LBRACE) {
+		p.advanceToken()
+		arm.Body = p.parseBlockStatement()
+	} else {
+		p.advanceToken()
+		arm.Body = p.parseExpression(LOWEST)
+	}
+
+	if arm.Body == nil {
+		return nil
+	}
+	return arm
+}
+
+// reports whether the next token can start the pattern in a let statement or
+// function parameter list, which (unlike match arms) can't be a bare literal.
+func (p *Parser) nextTokenIsBindingPattern() bool {
+	return p.nextTokenIs(token.IDENTIFIER) || p.nextTokenIs(token.LBRACKET) || p.nextTokenIs(token.LBRACE)
+}
+
+// parses a pattern starting at the current token, leaving the parser on the last
+// token of the pattern.
+func (p *Parser) parsePattern() ast.Pattern {
+	switch p.currentToken.Type {
+	case token.IDENTIFIER:
+		return p.parseNamePattern()
+	case token.INT:
+		return p.parseInteger()
+	case token.STRING:
+		return p.parseStringLiteral()
+	case token.TRUE, token.FALSE:
+		return p.parseBoolean()
+	case token.NULL:
+		return p.parseNull()
+	case token.MINUS:
+		if !p.nextTokenIs(token.INT) {
+			break
+		}
+		return p.parsePrefixExpression()
+	case token.LBRACKET:
+		return p.parseArrayPattern()
+	case token.LBRACE:
+		return p.parseHashPattern()
+	}
+
+	p.errors = append(p.errors, fmt.Errorf("unexpected token in pattern: %s", p.currentToken))
+	return nil
+}
+
+// parses an identifier, optionally followed by members (`Shape.Empty`) and then
+// by the arguments of a constructor pattern (`Shape.Circle(r)`).
+func (p *Parser) parseNamePattern() ast.Pattern {
+	name := p.parseIdentifier()
+	for p.nextTokenIs(token.DOT) {
+		p.advanceToken()
+		if name = p.parseMemberExpression(name); name == nil {
+			return nil
+		}
+	}
+
+	if !p.nextTokenIs(token.LPAREN) {
+		return name
+	}
+	p.advanceToken()
+
+	pattern := &ast.ConstructorPattern{Token: p.currentToken, Constructor: name, Arguments: []ast.Pattern{}}
+	for !p.nextTokenIs(token.RPAREN) {
+		p.advanceToken()
+
+		argument := p.parsePattern()
+		if argument == nil {
+			return nil
+		}
+		pattern.Arguments = append(pattern.Arguments, argument)
+
+		if !p.nextTokenIs(token.RPAREN) && !p.expectAndAdvance(token.COMMA) {
+			return nil
+		}
+	}
+	p.advanceToken()
+
+	return pattern
+}
+
+// parses `[<pattern>, ..., ...<identifier>]`.
+func (p *Parser) parseArrayPattern() ast.Pattern {
+	pattern := &ast.ArrayPattern{Token: p.currentToken}
+
+	for !p.nextTokenIs(token.RBRACKET) {
+		p.advanceToken()
+
+		if p.currentTokenIs(token.ELLIPSIS) {
+			if pattern.Rest = p.parseRestIdentifier(); pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+
+		element := p.parsePattern()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+
+		if !p.nextTokenIs(token.RBRACKET) && !p.expectAndAdvance(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectAndAdvance(token.RBRACKET) {
+		return nil
+	}
+
+	return pattern
+}
+
+// parses `{<key>: <pattern>, <identifier>, ..., ...<identifier>}`. Identifier keys
+// are treated as string keys, and a lone identifier binds the value of the key
+// with the same name.
+func (p *Parser) parseHashPattern() ast.Pattern {
+	pattern := &ast.HashPattern{Token: p.currentToken}
+
+	for !p.nextTokenIs(token.RBRACE) {
+		p.advanceToken()
+
+		if p.currentTokenIs(token.ELLIPSIS) {
+			if pattern.Rest = p.parseRestIdentifier(); pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+
+		pair := p.parseHashPatternPair()
+		if pair == nil {
+			return nil
+		}
+		pattern.Pairs = append(pattern.Pairs, pair)
+
+		if !p.nextTokenIs(token.RBRACE) && !p.expectAndAdvance(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectAndAdvance(token.RBRACE) {
+		return nil
+	}
+
+	return pattern
+}
+
+func (p *Parser) parseHashPatternPair() *ast.HashPatternPair {
+	var key ast.Expression
+
+	switch p.currentToken.Type {
+	case token.IDENTIFIER:
+		key = &ast.String{Token: p.currentToken, Value: p.currentToken.Value}
+
+		if !p.nextTokenIs(token.COLON) {
+			return &ast.HashPatternPair{Key: key, Value: p.parseIdentifier()}
+		}
+	case token.STRING:
+		key = p.parseStringLiteral()
+	case token.INT:
+		key = p.parseInteger()
+	case token.TRUE, token.FALSE:
+		key = p.parseBoolean()
+	default:
+		p.errors = append(p.errors, fmt.Errorf("unexpected token in hash pattern key: %s", p.currentToken))
+		return nil
+	}
+
+	if !p.expectAndAdvance(token.COLON) {
+		return nil
+	}
+
+	p.advanceToken()
+	value := p.parsePattern()
+	if value == nil {
+		return nil
+	}
+
+	return &ast.HashPatternPair{Key: key, Value: value}
+}
+
+// parses the identifier following `...` in an array or hash pattern, which must
+// be the last element of the pattern.
+func (p *Parser) parseRestIdentifier() *ast.Identifier {
+	if !p.expectAndAdvance(token.IDENTIFIER) {
+		return nil
+	}
+
+	rest := &ast.Identifier{Token: p.currentToken, Value: p.currentToken.Value}
+
+	if p.nextTokenIs(token.COMMA) {
+		p.errors = append(p.errors, fmt.Errorf("rest element must be the last element of a pattern"))
+		return nil
+	}
+
+	return rest
+}
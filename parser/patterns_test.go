@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"monkey-interpreter/ast"
+	"monkey-interpreter/lexer"
+	"testing"
+)
+
+func TestMatchExpressionParsing(t *testing.T) {
+	input := `match (x) {
+		0 => "zero",
+		[a, ...rest] if a > 1 => a,
+		{"kind": "circle", radius} => { radius * radius },
+		_ => null
+	}`
+
+	p := New(lexer.New(input))
+	program := p.ParseProgram()
+	checkParserHasNoErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("AST contained %d statements, expected 1", len(program.Statements))
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.MatchExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not ast.MatchExpression. got=%T", stmt.Expression)
+	}
+
+	testIdentifierLiteral(t, exp.Subject, "x")
+
+	if len(exp.Arms) != 4 {
+		t.Fatalf("wrong number of match arms. want 4, got=%d", len(exp.Arms))
+	}
+
+	testIntegerLiteral(t, exp.Arms[0].Pattern, 0)
+
+	array, ok := exp.Arms[1].Pattern.(*ast.ArrayPattern)
+	if !ok {
+		t.Fatalf("arm 1 pattern is not ast.ArrayPattern. got=%T", exp.Arms[1].Pattern)
+	}
+	if len(array.Elements) != 1 || array.Rest == nil {
+		t.Fatalf("array pattern parsed incorrectly. got=%s", array)
+	}
+	testIdentifierLiteral(t, array.Elements[0], "a")
+	testIdentifierLiteral(t, array.Rest, "rest")
+	testInfixExpression(t, exp.Arms[1].Guard, "a", ">", 1)
+
+	hash, ok := exp.Arms[2].Pattern.(*ast.HashPattern)
+	if !ok {
+		t.Fatalf("arm 2 pattern is not ast.HashPattern. got=%T", exp.Arms[2].Pattern)
+	}
+	if len(hash.Pairs) != 2 {
+		t.Fatalf("wrong number of hash pattern pairs. want 2, got=%d", len(hash.Pairs))
+	}
+	if key := hash.Pairs[1].Key.(*ast.String); key.Value != "radius" {
+		t.Errorf("shorthand hash pattern key wrong. got=%q", key.Value)
+	}
+	testIdentifierLiteral(t, hash.Pairs[1].Value, "radius")
+
+	if _, ok := exp.Arms[2].Body.(*ast.BlockStatement); !ok {
+		t.Errorf("arm 2 body is not ast.BlockStatement. got=%T", exp.Arms[2].Body)
+	}
+
+	testIdentifierLiteral(t, exp.Arms[3].Pattern, "_")
+}
+
+func TestPatternParsingErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"match (x) { [...a, b] => 1 }", "rest element must be the last element of a pattern"},
+		{"match (x) { a + 1 => 1 }", "expected token =>, got {+ +}"},
+		{"match (x) { fn => 1 }", "unexpected token in pattern: {FUNCTION fn}"},
+		{"match (x) { 1 => 1", "unterminated match expression"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q", tt.input)
+			continue
+		}
+
+		if p.Errors()[0].Error() != tt.expectedError {
+			t.Errorf("wrong error for %q. want=%q, got=%q", tt.input, tt.expectedError, p.Errors()[0])
+		}
+	}
+}
+
+func TestDestructuringParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b, ...rest] = arr;", "let [a, b, ...rest] = arr;"},
+		{"let {name, age} = person;", "let {name:name, age:age} = person;"},
+		{"let {name: n, ...others} = person;", "let {name:n, ...others} = person;"},
+		{"let [{x}, [y, _]] = points;", "let [{x:x}, [y, _]] = points;"},
+		{"fn([a, b], {c}, d) { a }", "func ([a, b],{c:c},d)a"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		program := p.ParseProgram()
+		checkParserHasNoErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+
+	p := New(lexer.New("let [a, b] = arr;"))
+	program := p.ParseProgram()
+	checkParserHasNoErrors(t, p)
+
+	letStmt := program.Statements[0].(*ast.LetStatement)
+	if _, ok := letStmt.Name.(*ast.ArrayPattern); !ok {
+		t.Errorf("letStmt.Name is not ast.ArrayPattern. got=%T", letStmt.Name)
+	}
+}
+
+func TestDestructuringParsingErrors(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{"let 5 = x;", "expected token IDENTIFIER, got {INTEGER 5}"},
+		{"fn(a, 5) { a }", "expected token IDENTIFIER, got {INTEGER 5}"},
+		{"let [a, ...b, c] = x;", "rest element must be the last element of a pattern"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input))
+		p.ParseProgram()
+
+		if len(p.Errors()) == 0 {
+			t.Errorf("expected parser errors for %q", tt.input)
+			continue
+		}
+
+		if p.Errors()[0].Error() != tt.expectedError {
+			t.Errorf("wrong error for %q. want=%q, got=%q", tt.input, tt.expectedError, p.Errors()[0])
+		}
+	}
+}
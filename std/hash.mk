@@ -0,0 +1,3 @@
+export let isEmpty = fn(h) { len(h) == 0 };
+
+export let get = fn(h, key, fallback) { has(h, key) ? h[key] : fallback };
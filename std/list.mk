@@ -0,0 +1,5 @@
+export let sum = fn(arr) { reduce(arr, 0, fn(total, x) { total + x }) };
+
+export let contains = fn(arr, value) { any(arr, fn(x) { x == value }) };
+
+export let isEmpty = fn(arr) { len(arr) == 0 };
@@ -0,0 +1,7 @@
+export let clamp = fn(x, low, high) { max(low, min(x, high)) };
+
+export let sign = fn(x) { x < 0 ? -1 : x > 0 ? 1 : 0 };
+
+export let isEven = fn(x) { mod(x, 2) == 0 };
+
+export let isOdd = fn(x) { !isEven(x) };
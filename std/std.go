@@ -0,0 +1,9 @@
+// Package std embeds the parts of Monkey's standard library that are written in
+// Monkey. Each std/<name> module is evaluated from <name>.mk, with the builtins
+// backing the module (registered by the evaluator) already in scope.
+package std
+
+import "embed"
+
+//go:embed *.mk
+var Sources embed.FS
@@ -0,0 +1,9 @@
+import { filter } from "std/list";
+
+export let length = fn(s) { len(s) };
+
+export let isEmpty = fn(s) { len(s) == 0 };
+
+export let surround = fn(s, left, right) { left + s + right };
+
+export let words = fn(s) { filter(split(s, " "), fn(word) { !isEmpty(word) }) };
@@ -0,0 +1,11 @@
+export let SECOND = 1000;
+
+export let MINUTE = 60 * SECOND;
+
+export let HOUR = 60 * MINUTE;
+
+export let measure = fn(f) {
+	let start = now();
+	f();
+	since(start);
+};
@@ -18,6 +18,22 @@ const (
 	RETURN     = "RETURN"
 	TRUE       = "TRUE"
 	FALSE      = "FALSE"
+	NULL       = "NULL"
+	MATCH      = "MATCH"
+	TRY        = "TRY"
+	CATCH      = "CATCH"
+	FINALLY    = "FINALLY"
+	THROW      = "THROW"
+	MACRO      = "MACRO"
+	IMPORT     = "IMPORT"
+	EXPORT     = "EXPORT"
+	FOR        = "FOR"
+	IN         = "IN"
+	STRUCT     = "STRUCT"
+	CLASS      = "CLASS"
+	ENUM       = "ENUM"
+	YIELD      = "YIELD"
+	SPAWN      = "SPAWN"
 
 	GRT = ">"
 	LES = "<"
@@ -27,8 +43,11 @@ const (
 	MINUS    = "-"
 	SLASH    = "/"
 	ASTERISK = "*"
+	PIPE     = "|"
+	AMP      = "&"
 
 	LBRACE    = "{"
+	SET_BRACE = "#{"
 	RBRACE    = "}"
 	LPAREN    = "("
 	RPAREN    = ")"
@@ -37,10 +56,18 @@ const (
 	SEMICOLON = ";"
 	COMMA     = ","
 	COLON     = ":"
+	QUESTION  = "?"
+	ARROW     = "=>"
+	ELLIPSIS  = "..."
+	DOT       = "."
 
 	EQ     = "=="
 	NOT_EQ = "!="
 
+	NULLISH      = "??"
+	OPT_DOT      = "?."
+	OPT_LBRACKET = "?["
+
 	ASSIGN = "="
 
 	ILLEGAL = "ILLEGAL"
@@ -55,13 +82,29 @@ const (
 )
 
 var keywords = map[string]TokenType{
-	"fn":     FUNCTION,
-	"let":    LET,
-	"if":     IF,
-	"else":   ELSE,
-	"return": RETURN,
-	"true":   TRUE,
-	"false":  FALSE,
+	"fn":      FUNCTION,
+	"let":     LET,
+	"if":      IF,
+	"else":    ELSE,
+	"return":  RETURN,
+	"true":    TRUE,
+	"false":   FALSE,
+	"null":    NULL,
+	"match":   MATCH,
+	"try":     TRY,
+	"catch":   CATCH,
+	"finally": FINALLY,
+	"throw":   THROW,
+	"macro":   MACRO,
+	"import":  IMPORT,
+	"export":  EXPORT,
+	"for":     FOR,
+	"in":      IN,
+	"struct":  STRUCT,
+	"class":   CLASS,
+	"enum":    ENUM,
+	"yield":   YIELD,
+	"spawn":   SPAWN,
 }
 
 type Token struct {
@@ -52,6 +52,22 @@ func TestGetKeywordType(t *testing.T) {
 		{"return", true, RETURN},
 		{"true", true, TRUE},
 		{"false", true, FALSE},
+		{"null", true, NULL},
+		{"match", true, MATCH},
+		{"try", true, TRY},
+		{"catch", true, CATCH},
+		{"finally", true, FINALLY},
+		{"throw", true, THROW},
+		{"macro", true, MACRO},
+		{"import", true, IMPORT},
+		{"export", true, EXPORT},
+		{"for", true, FOR},
+		{"in", true, IN},
+		{"struct", true, STRUCT},
+		{"class", true, CLASS},
+		{"enum", true, ENUM},
+		{"yield", true, YIELD},
+		{"spawn", true, SPAWN},
 		{"fail", false, ""},
 		{"", false, ""},
 	}